@@ -0,0 +1,184 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mczkzk/go-ts-generator/pkg/generator"
+)
+
+func printDiffHelp() {
+	fmt.Println("go-ts-generator diff - Detect breaking changes between two versions of the Go types")
+	fmt.Println("")
+	fmt.Println("Usage:")
+	fmt.Println("  go-ts-generator diff [options] <old> <new>")
+	fmt.Println("")
+	fmt.Println("Arguments:")
+	fmt.Println("  <old>, <new> - Comma-separated source directories, a model saved as JSON, or a git")
+	fmt.Println("                 revision and its source directories (e.g. main:./api,./models)")
+	fmt.Println("")
+	fmt.Println("Options:")
+	fmt.Println("  --json            - Print the changes as JSON")
+	fmt.Println("  --discover-routes - Discover routes from router setup code in both versions")
+	fmt.Println("")
+	fmt.Println("Exit status: 0 without breaking changes, 1 with breaking changes, 2 on errors")
+}
+
+// runDiff runs the diff subcommand and returns its exit status
+func runDiff(args []string) int {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	flags.Usage = printDiffHelp
+	jsonOutput := flags.Bool("json", false, "")
+	discoverRoutes := flags.Bool("discover-routes", false, "")
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() != 2 {
+		fmt.Println("Error: diff needs the old and new versions")
+		printDiffHelp()
+		return 2
+	}
+
+	opts := generator.DefaultOptions()
+	opts.DiscoverRoutes = *discoverRoutes
+
+	var models [2]*generator.Model
+	for i, arg := range flags.Args() {
+		model, err := loadModel(arg, opts)
+		if err != nil {
+			fmt.Printf("Error loading %s: %v\n", arg, err)
+			return 2
+		}
+		models[i] = model
+	}
+
+	changes := generator.DiffModels(models[0], models[1])
+	write := generator.WriteDiffReport
+	if *jsonOutput {
+		write = generator.WriteDiffJSON
+	}
+	if err := write(os.Stdout, changes); err != nil {
+		fmt.Printf("Error writing changes: %v\n", err)
+		return 2
+	}
+	if generator.HasBreakingChanges(changes) {
+		return 1
+	}
+	return 0
+}
+
+// loadModel loads the model of one side of a diff: a model saved as JSON, source
+// directories, or source directories at a git revision
+func loadModel(arg string, opts generator.Options) (*generator.Model, error) {
+	if strings.HasSuffix(arg, ".json") {
+		return generator.ReadIR(arg)
+	}
+
+	dirs := splitList(arg)
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no source directories")
+	}
+	if _, err := os.Stat(dirs[0]); err == nil {
+		return generator.CollectModel(dirs, opts)
+	}
+
+	rev, dirList, ok := strings.Cut(arg, ":")
+	if !ok {
+		return nil, fmt.Errorf("no such directory")
+	}
+	return collectRevision(rev, splitList(dirList), opts)
+}
+
+// collectRevision collects the model of source directories at a git revision, extracted
+// to a temporary directory with git archive. The directories are relative to the current
+// directory, which may be a subdirectory of the repository.
+func collectRevision(rev string, dirs []string, opts generator.Options) (*generator.Model, error) {
+	toplevel, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return nil, fmt.Errorf("error finding the git repository: %v", err)
+	}
+	prefix, err := exec.Command("git", "rev-parse", "--show-prefix").Output()
+	if err != nil {
+		return nil, fmt.Errorf("error finding the git repository: %v", err)
+	}
+
+	// Paths in the archive are relative to the repository root, so the archive is created
+	// there with the directories as repository paths
+	paths := make([]string, len(dirs))
+	for i, dir := range dirs {
+		paths[i] = filepath.ToSlash(filepath.Join(strings.TrimSpace(string(prefix)), dir))
+		if paths[i] == ".." || strings.HasPrefix(paths[i], "../") {
+			return nil, fmt.Errorf("directory %s is outside the git repository", dir)
+		}
+	}
+
+	var archive bytes.Buffer
+	args := append([]string{"-C", strings.TrimSpace(string(toplevel)), "archive", "--format=tar", rev, "--"}, paths...)
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = &archive
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error reading revision %s: %v", rev, err)
+	}
+
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-diff")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+	if err := extractTar(&archive, tempDir); err != nil {
+		return nil, err
+	}
+
+	sourceDirs := make([]string, len(paths))
+	for i, path := range paths {
+		sourceDirs[i] = filepath.Join(tempDir, filepath.FromSlash(path))
+	}
+	return generator.CollectModel(sourceDirs, opts)
+}
+
+// extractTar extracts the directories and regular files of a tar archive to dir
+func extractTar(r io.Reader, dir string) error {
+	reader := tar.NewReader(r)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading archive: %v", err)
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid path in archive: %s", header.Name)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			data, err := io.ReadAll(reader)
+			if err != nil {
+				return fmt.Errorf("error reading archive: %v", err)
+			}
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/mczkzk/go-ts-generator/pkg/generator"
+)
+
+// writeFiles writes files given by slash-separated paths relative to dir
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+// chdir changes the working directory until the end of the test
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get the working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	t.Cleanup(func() {
+		os.Chdir(wd)
+	})
+}
+
+// typeNames returns the names of the types of a model
+func typeNames(model *generator.Model) []string {
+	var names []string
+	for _, t := range model.Types {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+const userV1 = `package api
+
+// User is a user
+type User struct {
+	ID    int64  ` + "`json:\"id\"`" + `
+	Email string ` + "`json:\"email\"`" + `
+}
+`
+
+const userV2 = `package api
+
+// User is a user without an email
+type User struct {
+	ID int64 ` + "`json:\"id\"`" + `
+}
+`
+
+// TestLoadModel tests loading a side of a diff from source directories and saved models
+func TestLoadModel(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-cli-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	writeFiles(t, tempDir, map[string]string{"api/user.go": userV1})
+
+	opts := generator.DefaultOptions()
+	apiDir := filepath.Join(tempDir, "api")
+	model, err := loadModel(apiDir, opts)
+	if err != nil {
+		t.Fatalf("loadModel failed for a directory: %v", err)
+	}
+	if names := typeNames(model); len(names) != 1 || names[0] != "User" {
+		t.Errorf("Expected the User type, got %v", names)
+	}
+
+	irPath := filepath.Join(tempDir, "model.json")
+	if err := generator.WriteIR(model, irPath); err != nil {
+		t.Fatalf("WriteIR failed: %v", err)
+	}
+	saved, err := loadModel(irPath, opts)
+	if err != nil {
+		t.Fatalf("loadModel failed for a saved model: %v", err)
+	}
+	if names := typeNames(saved); len(names) != 1 || names[0] != "User" {
+		t.Errorf("Expected the User type in the saved model, got %v", names)
+	}
+
+	for _, arg := range []string{filepath.Join(tempDir, "missing"), ","} {
+		if _, err := loadModel(arg, opts); err == nil {
+			t.Errorf("Expected an error loading %q", arg)
+		}
+	}
+}
+
+// TestCollectRevision tests collecting source directories at a git revision from a
+// subdirectory of the repository
+func TestCollectRevision(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-cli-git-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	writeFiles(t, tempDir, map[string]string{"services/api/user.go": userV1})
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = tempDir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "Add the user type")
+
+	// The working tree changes after the commit
+	writeFiles(t, tempDir, map[string]string{"services/api/user.go": userV2})
+
+	chdir(t, filepath.Join(tempDir, "services"))
+	model, err := loadModel("HEAD:./api", generator.DefaultOptions())
+	if err != nil {
+		t.Fatalf("loadModel failed for a revision: %v", err)
+	}
+	if len(model.Types) != 1 || len(model.Types[0].Fields) != 2 {
+		t.Errorf("Expected the committed User type with two fields, got %+v", model.Types)
+	}
+
+	if _, err := collectRevision("HEAD", []string{"../.."}, generator.DefaultOptions()); err == nil {
+		t.Error("Expected an error for a directory outside the repository")
+	}
+	if _, err := collectRevision("unknown-revision", []string{"api"}, generator.DefaultOptions()); err == nil {
+		t.Error("Expected an error for an unknown revision")
+	}
+}
+
+// TestRunDiffExitStatus tests the exit status of the diff subcommand
+func TestRunDiffExitStatus(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-cli-diff-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	writeFiles(t, tempDir, map[string]string{
+		"v1/user.go":      userV1,
+		"v1copy/user.go":  userV1,
+		"v2/user.go":      userV2,
+		"v2added/user.go": userV1 + "\n// Team is a team\ntype Team struct {\n\tName string `json:\"name\"`\n}\n",
+	})
+	dir := func(name string) string {
+		return filepath.Join(tempDir, name)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"no changes", []string{dir("v1"), dir("v1copy")}, 0},
+		{"added type", []string{dir("v1"), dir("v2added")}, 0},
+		{"removed field", []string{"--json", dir("v1"), dir("v2")}, 1},
+		{"missing version", []string{dir("v1")}, 2},
+		{"unknown directory", []string{dir("v1"), dir("missing")}, 2},
+		{"unknown flag", []string{"--unknown", dir("v1"), dir("v2")}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runDiff(tt.args); got != tt.want {
+				t.Errorf("Expected exit status %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/mczkzk/go-ts-generator/pkg/generator"
@@ -19,57 +21,385 @@ func printHelp() {
 	fmt.Println("")
 	fmt.Println("Usage:")
 	fmt.Println("  go-ts-generator [options] <source_dirs> <target_file>")
+	fmt.Println("  go-ts-generator [options] --from-ir <model.json> <target_file>")
+	fmt.Println("  go-ts-generator [options] --out <name>=<file> ... <source_dirs>")
+	fmt.Println("  go-ts-generator diff [options] <old> <new>")
+	fmt.Println("  //go:generate go-ts-generator [options]  (outputs declared with //tsgen:out <file>)")
 	fmt.Println("")
 	fmt.Println("Arguments:")
 	fmt.Println("  <source_dirs> - Comma-separated list of directories containing Go files to parse")
 	fmt.Println("                  Example: dir1,dir2,dir3")
 	fmt.Println("  <target_file> - Target TypeScript file to generate")
+	fmt.Println("                  Other formats are written next to it (e.g. types.schema.json)")
 	fmt.Println("")
 	fmt.Println("Options:")
-	fmt.Println("  --help     - Show this help message")
-	fmt.Println("  --version  - Show version information")
+	fmt.Println("  --help                     - Show this help message")
+	fmt.Println("  --version                  - Show version information")
+	fmt.Println("  --api-rules <rules>        - Comma-separated signals that mark a type as API-related")
+	fmt.Println("                               (swagger,handlers,annotations,transitive; default: all)")
+	fmt.Println("  --api-name-pattern <regex> - Also mark types whose name matches the pattern (repeatable)")
+	fmt.Println("  --api-out <file>           - Write API types to a separate file")
+	fmt.Println("  --classification-report    - Print the API type classification to stderr")
+	fmt.Println("  --time-as-date             - Map time.Time to Date and generate parseX/serializeX helpers")
+	fmt.Println("  --discover-routes          - Discover routes from gin, echo, chi and net/http router setup code")
+	fmt.Println("  --interface-unions         - Render Go interfaces as unions of the types implementing them")
+	fmt.Println("  --property-naming <name>   - Naming of untagged fields (preserve,camelCase,snake_case,PascalCase;")
+	fmt.Println("                               default: preserve)")
+	fmt.Println("  --property-naming-all      - Apply --property-naming to tagged fields too")
+	fmt.Println("  --readonly <mode>          - Emit readonly properties and collections (none,all,responses;")
+	fmt.Println("                               default: none)")
+	fmt.Println("  --type-prefix <prefix>     - Add a prefix to every type name (e.g. I)")
+	fmt.Println("  --type-suffix <suffix>     - Add a suffix to every type name (e.g. DTO)")
+	fmt.Println("  --type-trim-prefix <list>  - Comma-separated prefixes removed from type names")
+	fmt.Println("  --type-trim-suffix <list>  - Comma-separated suffixes removed from type names (e.g. Response)")
+	fmt.Println("  --qualify-packages         - Prefix type names with their Go package name")
+	fmt.Println("  --unexported-types <mode>  - Handling of unexported types (keep,export,skip; default: keep)")
+	fmt.Println("  --emit-ir <file>           - Write the collected model as JSON for other tools")
+	fmt.Println("  --from-ir <file>           - Render from a model written with --emit-ir instead of the Go sources")
+	fmt.Println("  --format <formats>         - Comma-separated output formats (ts,jsonschema,openapi,openapi-json,routes,")
+	fmt.Println("                               tanstack,swr,mocks,guards; default: ts)")
+	fmt.Println("  --out <name>=<file>        - Write the output of a renderer to a file (repeatable, e.g. openapi=api.yaml);")
+	fmt.Println("                               renderers: " + strings.Join(generator.RendererNames(), ","))
+	fmt.Println("  --cache-dir <dir>          - Directory of the models merged into outputs shared by several packages")
+	fmt.Println("                               under go:generate (default: go-ts-generator in the user cache directory)")
+}
+
+// formatExtensions maps output formats to the extension of their files
+var formatExtensions = map[string]string{
+	"ts":           ".ts",
+	"jsonschema":   ".schema.json",
+	"openapi":      ".openapi.yaml",
+	"openapi-json": ".openapi.json",
+	"routes":       ".routes.ts",
+	"tanstack":     ".queries.ts",
+	"swr":          ".swr.ts",
+	"mocks":        ".mocks.ts",
+	"guards":       ".guards.ts",
+}
+
+// typeModuleFormats lists the formats importing the TypeScript types, which are always
+// written next to the TypeScript output
+var typeModuleFormats = map[string]bool{
+	"routes":   true,
+	"tanstack": true,
+	"swr":      true,
+	"mocks":    true,
+	"guards":   true,
+}
+
+// output is a renderer and the file it writes to
+type output struct {
+	Renderer string
+	Path     string
+}
+
+// directiveOutputs returns the outputs declared with //tsgen:out by the package run by
+// go:generate, in the current directory. Only the files of $GOPACKAGE are read.
+func directiveOutputs() ([]output, error) {
+	targets, err := generator.PackageTargets(".", os.Getenv("GOPACKAGE"))
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no //tsgen:out directive in package %s", os.Getenv("GOPACKAGE"))
+	}
+
+	var outputs []output
+	for _, target := range targets {
+		outputs = append(outputs, output{Renderer: target.Renderer, Path: target.Path})
+	}
+	return outputs, nil
+}
+
+// outputDescriptions describes the outputs of the built-in renderers in messages
+var outputDescriptions = map[string]string{
+	"ts":           "TypeScript type definitions",
+	"jsonschema":   "JSON Schema",
+	"openapi":      "OpenAPI document",
+	"openapi-json": "OpenAPI document",
+	"ir":           "Intermediate representation",
+	"routes":       "TypeScript route map",
+	"tanstack":     "React hooks",
+	"swr":          "React hooks",
+	"mocks":        "Mock factories",
+	"guards":       "Type guards",
+}
+
+// outputDescription describes the output of a renderer in messages
+func outputDescription(renderer string) string {
+	if description, ok := outputDescriptions[renderer]; ok {
+		return description
+	}
+	return renderer + " output"
+}
+
+// outputPath returns the file a format is written to. The target file is used as is when
+// it is the only output or the TypeScript output; other formats replace its extension.
+func outputPath(targetFile, format string, formats []string) string {
+	if format == "ts" || (len(formats) == 1 && !typeModuleFormats[format]) {
+		return targetFile
+	}
+	return strings.TrimSuffix(targetFile, filepath.Ext(targetFile)) + formatExtensions[format]
+}
+
+// splitList splits a comma-separated flag value, ignoring empty items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// containsFormat checks if format is one of the formats
+func containsFormat(formats []string, format string) bool {
+	for _, f := range formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// stringList is a flag.Value that collects repeated flag values
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
 }
 
 func main() {
+	flags := flag.NewFlagSet("go-ts-generator", flag.ContinueOnError)
+	flags.Usage = printHelp
+
+	showVersion := flags.Bool("version", false, "")
+	apiRules := flags.String("api-rules", "swagger,handlers,annotations,transitive", "")
+	apiOut := flags.String("api-out", "", "")
+	report := flags.Bool("classification-report", false, "")
+	timeAsDate := flags.Bool("time-as-date", false, "")
+	formatList := flags.String("format", "ts", "")
+	var outs stringList
+	flags.Var(&outs, "out", "")
+	discoverRoutes := flags.Bool("discover-routes", false, "")
+	interfaceUnions := flags.Bool("interface-unions", false, "")
+	propertyNaming := flags.String("property-naming", "preserve", "")
+	propertyNamingAll := flags.Bool("property-naming-all", false, "")
+	readonly := flags.String("readonly", "none", "")
+	typePrefix := flags.String("type-prefix", "", "")
+	typeSuffix := flags.String("type-suffix", "", "")
+	typeTrimPrefix := flags.String("type-trim-prefix", "", "")
+	typeTrimSuffix := flags.String("type-trim-suffix", "", "")
+	qualifyPackages := flags.Bool("qualify-packages", false, "")
+	unexportedTypes := flags.String("unexported-types", "keep", "")
+	emitIR := flags.String("emit-ir", "", "")
+	fromIR := flags.String("from-ir", "", "")
+	cacheDir := flags.String("cache-dir", "", "")
+	var namePatterns stringList
+	flags.Var(&namePatterns, "api-name-pattern", "")
+
+	// Show help when no arguments are given, unless run by go:generate
+	if len(os.Args) <= 1 && os.Getenv("GOFILE") == "" {
+		printHelp()
+		os.Exit(1)
+	}
+
+	// The diff subcommand compares two versions of the Go types
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		os.Exit(runDiff(os.Args[2:]))
+	}
+
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		// --help and -h print the help message and exit successfully
+		if err == flag.ErrHelp {
+			return
+		}
+		os.Exit(1)
+	}
+
 	// Check for --version flag
-	if len(os.Args) > 1 && os.Args[1] == "--version" {
+	if *showVersion {
 		fmt.Printf("go-ts-generator version %s\n", Version)
 		return
 	}
 
-	// Check for --help flag
-	if len(os.Args) <= 1 || os.Args[1] == "--help" || os.Args[1] == "-h" {
-		printHelp()
-		if len(os.Args) <= 1 {
+	// Get source directories and target file from command-line arguments. The source
+	// directories are left out when rendering from a saved model.
+	args := flags.Args()
+	var sourceDirs []string
+	var outputs []output
+	// Run by go:generate: the outputs are declared with //tsgen:out in the package, and each
+	// one is rendered from the models of every package declaring it
+	directives := *fromIR == "" && len(args) == 0 && len(outs) == 0 && os.Getenv("GOFILE") != ""
+	if directives {
+		var err error
+		outputs, err = directiveOutputs()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		sourceDirs = []string{"."}
+	} else {
+		if *fromIR == "" && len(args) > 0 {
+			sourceDirs = splitList(args[0])
+			args = args[1:]
+		}
+		var targetFile string
+		if len(args) > 0 {
+			targetFile = args[0]
+		}
+		// The flag package stops at the first argument, so flags written after the
+		// arguments would be dropped
+		if len(args) > 1 {
+			fmt.Printf("Error: unexpected argument %q, options go before the source directories and target file\n", args[1])
+			os.Exit(1)
+		}
+		if (*fromIR == "" && len(sourceDirs) == 0) || (targetFile == "" && len(outs) == 0) {
+			fmt.Println("Error: Missing required arguments")
+			printHelp()
 			os.Exit(1)
 		}
-		return
-	}
 
-	// Get source directories and target file from command-line arguments
-	if len(os.Args) < 3 {
-		fmt.Println("Error: Missing required arguments")
-		printHelp()
-		os.Exit(1)
+		// Outputs of the formats derived from the target file, followed by the --out outputs
+		if targetFile != "" {
+			formats := splitList(*formatList)
+			for _, format := range formats {
+				if _, ok := formatExtensions[format]; !ok {
+					fmt.Printf("Error: unknown format %q\n", format)
+					os.Exit(1)
+				}
+				outputs = append(outputs, output{Renderer: format, Path: outputPath(targetFile, format, formats)})
+			}
+		}
+		for _, out := range outs {
+			name, path, ok := strings.Cut(out, "=")
+			if !ok || name == "" || path == "" {
+				fmt.Printf("Error: invalid --out %q, expected <name>=<file>\n", out)
+				os.Exit(1)
+			}
+			outputs = append(outputs, output{Renderer: name, Path: path})
+		}
 	}
 
-	sourceDirsArg := os.Args[1]
-	targetFile := os.Args[2]
+	// Modules importing the TypeScript types need the TypeScript output
+	typesFile := ""
+	for _, out := range outputs {
+		if !containsFormat(generator.RendererNames(), out.Renderer) {
+			fmt.Printf("Error: unknown renderer %q (available: %s)\n", out.Renderer, strings.Join(generator.RendererNames(), ","))
+			os.Exit(1)
+		}
+		if out.Renderer == "ts" {
+			typesFile = out.Path
+		}
+	}
+	for _, out := range outputs {
+		if typeModuleFormats[out.Renderer] && typesFile == "" {
+			fmt.Printf("Error: format %q imports the TypeScript types, add ts to --format or --out\n", out.Renderer)
+			os.Exit(1)
+		}
+	}
 
-	// Split the source directories by comma
-	sourceDirs := strings.Split(sourceDirsArg, ",")
+	opts := generator.DefaultOptions()
+	rules, err := generator.ParseClassificationRules(*apiRules)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	rules.NamePatterns = namePatterns
+	opts.APIRules = rules
+	opts.APITypesFile = *apiOut
+	opts.TimeAsDate = *timeAsDate
+	opts.DiscoverRoutes = *discoverRoutes
+	opts.InterfaceUnions = *interfaceUnions
+	opts.PropertyNaming, err = generator.ParseNamingStrategy(*propertyNaming)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	opts.PropertyNamingAll = *propertyNamingAll
+	opts.Readonly, err = generator.ParseReadonlyMode(*readonly)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	opts.TypeNaming = generator.TypeNaming{
+		TrimPrefixes:    splitList(*typeTrimPrefix),
+		TrimSuffixes:    splitList(*typeTrimSuffix),
+		QualifyPackages: *qualifyPackages,
+		Prefix:          *typePrefix,
+		Suffix:          *typeSuffix,
+	}
+	opts.TypeNaming.Unexported, err = generator.ParseUnexportedTypes(*unexportedTypes)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
 
-	// Trim spaces from each directory path
-	for i, dir := range sourceDirs {
-		sourceDirs[i] = strings.TrimSpace(dir)
+	// go:generate collects the files of $GOPACKAGE in the current directory only
+	if directives {
+		opts.Package = os.Getenv("GOPACKAGE")
 	}
 
-	// Generate TypeScript types from multiple directories
-	err := generator.GenerateTypesFromMultipleDirs(sourceDirs, targetFile)
+	// Collect types from multiple directories, or load them from a saved model
+	var model *generator.Model
+	if *fromIR != "" {
+		model, err = generator.ReadIR(*fromIR)
+	} else {
+		model, err = generator.CollectModel(sourceDirs, opts)
+	}
 	if err != nil {
-		fmt.Printf("Error generating TypeScript types: %v\n", err)
+		fmt.Printf("Error collecting types: %v\n", err)
 		os.Exit(1)
 	}
+	if *report {
+		if err := generator.WriteClassificationReport(os.Stderr, model.Types); err != nil {
+			fmt.Printf("Error writing classification report: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
-	fmt.Printf("TypeScript type definitions generated: %s\n", targetFile)
+	if *emitIR != "" {
+		if err := generator.WriteIR(model, *emitIR); err != nil {
+			fmt.Printf("Error writing the intermediate representation: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Intermediate representation generated: %s\n", *emitIR)
+	}
+
+	for _, out := range outputs {
+		renderer, err := generator.LookupRenderer(out.Renderer, generator.RenderTarget{Path: out.Path, TypesFile: typesFile, Options: opts})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		outModel := model
+		if directives {
+			if *cacheDir == "" {
+				if *cacheDir, err = generator.DefaultCacheDir(); err != nil {
+					fmt.Printf("Error: %v, set --cache-dir\n", err)
+					os.Exit(1)
+				}
+			}
+			target := generator.OutputTarget{Renderer: out.Renderer, Path: out.Path}
+			if outModel, err = generator.MergeTargetModel(*cacheDir, ".", target, model); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		description := outputDescription(out.Renderer)
+		if err := generator.RenderToFile(renderer, outModel, out.Path); err != nil {
+			fmt.Printf("Error generating %s: %v\n", description, err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s generated: %s\n", description, out.Path)
+		if out.Renderer == "ts" && *apiOut != "" {
+			fmt.Printf("TypeScript API type definitions generated: %s\n", *apiOut)
+		}
+	}
 }
@@ -0,0 +1,305 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+)
+
+// ClassificationRules controls which signals mark a type as API-related
+type ClassificationRules struct {
+	Swagger      bool     // Types referenced from @Param/@Success annotations
+	Handlers     bool     // Types bound from requests or written to responses in handler bodies
+	Annotations  bool     // Types annotated with a //ts:api directive
+	Transitive   bool     // Types referenced from fields of other API types
+	NamePatterns []string // Regular expressions matched against type names
+}
+
+// DefaultClassificationRules returns the rules used when none are configured
+func DefaultClassificationRules() ClassificationRules {
+	return ClassificationRules{
+		Swagger:     true,
+		Handlers:    true,
+		Annotations: true,
+		Transitive:  true,
+	}
+}
+
+// ParseClassificationRules parses a comma-separated list of rule names
+// (swagger, handlers, annotations, transitive) into ClassificationRules
+func ParseClassificationRules(s string) (ClassificationRules, error) {
+	var rules ClassificationRules
+	for _, name := range strings.Split(s, ",") {
+		switch strings.TrimSpace(name) {
+		case "swagger":
+			rules.Swagger = true
+		case "handlers":
+			rules.Handlers = true
+		case "annotations":
+			rules.Annotations = true
+		case "transitive":
+			rules.Transitive = true
+		case "", "none":
+		default:
+			return rules, fmt.Errorf("unknown classification rule %q", name)
+		}
+	}
+	return rules, nil
+}
+
+// apiDirective is the comment directive that explicitly marks a type as API-related
+const apiDirective = "//ts:api"
+
+// ClassifyAPITypes sets IsAPIType and APIReasons on the types in typeMap based on the
+// signals found in the source directories and the given rules
+func ClassifyAPITypes(sourceDirs []string, typeMap map[string]*TypeScriptType, rules ClassificationRules) error {
+	ctx := context.Background()
+	var operations []Operation
+	if rules.Swagger {
+		for _, sourceDir := range sourceDirs {
+			ops, _, err := collectOperations(ctx, sourceDir)
+			if err != nil {
+				return fmt.Errorf("error collecting operations from directory %s: %w", sourceDir, err)
+			}
+			operations = append(operations, ops...)
+		}
+	}
+	return classifyAPITypes(ctx, sourceDirs, typeMap, operations, rules)
+}
+
+// classifyAPITypes classifies the API types, with the swagger signals of the annotated
+// operations, stopping when the context is cancelled
+func classifyAPITypes(ctx context.Context, sourceDirs []string, typeMap map[string]*TypeScriptType, operations []Operation, rules ClassificationRules) error {
+	for _, t := range typeMap {
+		t.IsAPIType = false
+		t.APIReasons = nil
+	}
+
+	var namePatterns []*regexp.Regexp
+	for _, pattern := range rules.NamePatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid name pattern %q: %w", pattern, err)
+		}
+		namePatterns = append(namePatterns, re)
+	}
+
+	if rules.Swagger {
+		collectSwaggerSignals(operations, typeMap)
+	}
+	for _, sourceDir := range sourceDirs {
+		if err := collectAPISignals(ctx, sourceDir, typeMap, rules); err != nil {
+			return fmt.Errorf("error collecting API signals from directory %s: %w", sourceDir, err)
+		}
+	}
+
+	// Name patterns are opt-in and only apply to the configured expressions
+	for name, t := range typeMap {
+		for _, re := range namePatterns {
+			if re.MatchString(name) {
+				addAPIReason(t, "name matches pattern "+re.String())
+			}
+		}
+	}
+
+	// Propagate the classification to types referenced from API types
+	if rules.Transitive {
+		var queue []*TypeScriptType
+		for _, t := range typeMap {
+			if t.IsAPIType {
+				queue = append(queue, t)
+			}
+		}
+		for len(queue) > 0 {
+			t := queue[0]
+			queue = queue[1:]
+			for _, field := range t.Fields {
+				for _, name := range fieldTypeNames(field) {
+					ref, exists := typeMap[name]
+					if !exists || ref == t {
+						continue
+					}
+					if !ref.IsAPIType {
+						queue = append(queue, ref)
+					}
+					addAPIReason(ref, "referenced by "+t.Name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// collectAPISignals walks the source directory and records the handler and annotation
+// signals enabled in rules
+func collectAPISignals(ctx context.Context, sourceDir string, typeMap map[string]*TypeScriptType, rules ClassificationRules) error {
+	return walkContext(ctx, sourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Process only Go files
+		if info.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		fset := token.NewFileSet()
+		node, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			fmt.Printf("Error parsing file %s: %v\n", path, err)
+			return nil
+		}
+
+		for _, decl := range node.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				if !rules.Annotations || d.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range d.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					if hasDirective(d.Doc, apiDirective) || hasDirective(typeSpec.Doc, apiDirective) {
+						if t, exists := typeMap[typeSpec.Name.Name]; exists {
+							addAPIReason(t, "annotated with "+apiDirective)
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				if !rules.Handlers || d.Body == nil {
+					continue
+				}
+				handler := analyzeHandler(d.Type.Params, d.Body)
+				for _, req := range handler.Requests {
+					if t, exists := typeMap[req.Name]; exists {
+						addAPIReason(t, fmt.Sprintf("handler: bound in %s (%s)", d.Name.Name, req.Method))
+					}
+				}
+				for _, resp := range handler.Responses {
+					if t, exists := typeMap[resp.Name]; exists {
+						addAPIReason(t, fmt.Sprintf("handler: written in %s (%s)", d.Name.Name, resp.Method))
+					}
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// collectSwaggerSignals records the types of the @Success, @Failure and @Param annotations
+// of the operations
+func collectSwaggerSignals(operations []Operation, typeMap map[string]*TypeScriptType) {
+	for _, op := range operations {
+		route := op.Method + " " + op.Path
+		for _, resp := range op.Responses {
+			annotation := "@Success"
+			if resp.Failure {
+				annotation = "@Failure"
+			}
+			for _, t := range swaggerTypes(resp.Type, typeMap) {
+				addAPIReason(t, fmt.Sprintf("swagger: %s on %s", annotation, route))
+			}
+		}
+		for _, param := range op.Parameters {
+			for _, t := range swaggerTypes(param.Type, typeMap) {
+				addAPIReason(t, fmt.Sprintf("swagger: @Param %s on %s", param.In, route))
+			}
+		}
+	}
+}
+
+// swaggerTypes returns the types referenced by the type of an annotation, including the
+// types of the composite aliases replacing composite types, e.g. User for Envelope{data=User}
+func swaggerTypes(ref *TypeRef, typeMap map[string]*TypeScriptType) []*TypeScriptType {
+	var types []*TypeScriptType
+	for _, name := range ref.NamedTypes() {
+		t, exists := typeMap[name]
+		if !exists {
+			continue
+		}
+		types = append(types, t)
+		// Composite aliases hold the composite type in their value field
+		if len(t.Fields) == 1 && t.Fields[0].TypeRef != nil && t.Fields[0].TypeRef.Kind == KindComposite {
+			types = append(types, swaggerTypes(t.Fields[0].TypeRef, typeMap)...)
+		}
+	}
+	return types
+}
+
+// addAPIReason marks a type as API-related and records why, skipping duplicate reasons
+func addAPIReason(t *TypeScriptType, reason string) {
+	t.IsAPIType = true
+	for _, existing := range t.APIReasons {
+		if existing == reason {
+			return
+		}
+	}
+	t.APIReasons = append(t.APIReasons, reason)
+}
+
+// hasDirective checks if a comment group contains the given directive on its own line
+func hasDirective(doc *ast.CommentGroup, directive string) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		text := strings.TrimSpace(c.Text)
+		if text == directive || strings.HasPrefix(text, directive+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// swaggerTypeName extracts the bare type name from a swagger type reference
+// e.g. []responses.User -> User
+func swaggerTypeName(ref string) string {
+	ref = strings.TrimPrefix(ref, "[]")
+	parts := strings.Split(ref, ".")
+	return parts[len(parts)-1]
+}
+
+// identifierRegex matches identifiers inside a TypeScript type expression
+var identifierRegex = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+// referencedTypeNames returns the identifiers used in a TypeScript type expression
+func referencedTypeNames(tsType string) []string {
+	return identifierRegex.FindAllString(tsType, -1)
+}
+
+// fieldTypeNames returns the names of the types referenced by a field, falling back to
+// the identifiers in the type string for fields without a TypeRef
+func fieldTypeNames(field TypeScriptField) []string {
+	if field.TypeRef != nil {
+		return field.TypeRef.NamedTypes()
+	}
+	return referencedTypeNames(field.Type)
+}
+
+// WriteClassificationReport writes a table showing whether each type was classified as
+// API-related and which signals caused it
+func WriteClassificationReport(w io.Writer, types []TypeScriptType) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TYPE\tCLASS\tREASONS")
+	for _, t := range types {
+		class := "model"
+		reasons := "no API signals"
+		if t.IsAPIType {
+			class = "api"
+			reasons = strings.Join(t.APIReasons, "; ")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", t.Name, class, reasons)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// compositeTypes replaces the composite request and response types of the operations with
+// references to named type aliases, which are returned in the order they were first used.
+// Composites with the same structure share one alias, named after the base type and the
+// override types, e.g. Envelope{data=[]User} -> EnvelopeUserList.
+func compositeTypes(operations []Operation, typeMap map[string]*TypeScriptType) []TypeScriptType {
+	var types []TypeScriptType
+	names := make(map[string]string) // TypeScript expression -> alias name
+
+	var replace func(ref *TypeRef) *TypeRef
+	replace = func(ref *TypeRef) *TypeRef {
+		if ref == nil {
+			return nil
+		}
+		switch ref.Kind {
+		case KindComposite:
+			expr := ref.String()
+			name, exists := names[expr]
+			if !exists {
+				name = compositeTypeName(ref)
+				// Avoid collisions with collected types and other composites
+				for typeMap[name] != nil || containsAlias(types, name) {
+					name += "Response"
+				}
+				names[expr] = name
+				types = append(types, compositeAlias(name, ref))
+			}
+			return namedRef(name)
+		case KindArray, KindMap, KindNullable:
+			clone := *ref
+			clone.Elem = replace(ref.Elem)
+			return &clone
+		}
+		return ref
+	}
+
+	for i := range operations {
+		op := &operations[i]
+		for j := range op.Responses {
+			op.Responses[j].Type = replace(op.Responses[j].Type)
+		}
+		for j := range op.Parameters {
+			op.Parameters[j].Type = replace(op.Parameters[j].Type)
+		}
+	}
+	return types
+}
+
+// compositeAlias returns the type alias declared for a composite type
+func compositeAlias(name string, ref *TypeRef) TypeScriptType {
+	var overrides []string
+	for _, override := range ref.Overrides {
+		overrides = append(overrides, override.Name+": "+override.Type.String())
+	}
+	return TypeScriptType{
+		Name:       name,
+		Comment:    fmt.Sprintf("%s is %s with %s", name, ref.Name, strings.Join(overrides, ", ")),
+		IsExported: true,
+		Fields: []TypeScriptField{{
+			Name:       "value",
+			Type:       ref.String(),
+			TypeRef:    ref,
+			IsExported: true,
+		}},
+	}
+}
+
+// containsAlias checks if a type with the given name is in types
+func containsAlias(types []TypeScriptType, name string) bool {
+	for _, t := range types {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// compositeTypeName returns a type name describing a TypeRef
+// e.g. Envelope{data=[]User} -> EnvelopeUserList, map[string]int -> NumberMap
+func compositeTypeName(ref *TypeRef) string {
+	if ref == nil {
+		return "Any"
+	}
+	switch ref.Kind {
+	case KindComposite:
+		name := ref.Name
+		for _, override := range ref.Overrides {
+			name += compositeTypeName(override.Type)
+		}
+		return name
+	case KindArray:
+		return compositeTypeName(ref.Elem) + "List"
+	case KindMap:
+		return compositeTypeName(ref.Elem) + "Map"
+	case KindNullable:
+		return compositeTypeName(ref.Elem)
+	case KindNamed:
+		return ref.Name
+	}
+
+	// Capitalize each word of primitive names: number | string -> NumberString
+	var name strings.Builder
+	for _, word := range strings.FieldsFunc(ref.Name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		name.WriteString(string(runes))
+	}
+	return name.String()
+}
@@ -0,0 +1,301 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// mapRecordHelper converts the values of the records of the date conversions
+const mapRecordHelper = `function mapRecord(record: any, fn: (value: any) => any): any {
+  const result: any = {};
+  for (const key of Object.keys(record)) {
+    result[key] = fn(record[key]);
+  }
+  return result;
+}
+`
+
+// isTimeRef checks if a TypeRef node was mapped from time.Time
+func isTimeRef(node *TypeRef) bool {
+	return node.Kind == KindPrimitive && node.GoType == "time.Time"
+}
+
+// walkValues calls fn for the node and its descendants like TypeRef.Walk, except map keys,
+// which are always strings in JSON
+func walkValues(ref *TypeRef, fn func(*TypeRef)) {
+	if ref == nil {
+		return
+	}
+	fn(ref)
+	walkValues(ref.Elem, fn)
+	for _, override := range ref.Overrides {
+		walkValues(override.Type, fn)
+	}
+	for _, member := range ref.Members {
+		walkValues(member, fn)
+	}
+}
+
+// applyDateType renders time.Time fields as Date instead of an RFC3339 string. Map keys
+// stay strings.
+func applyDateType(types []TypeScriptType) {
+	for i := range types {
+		for j := range types[i].Fields {
+			field := &types[i].Fields[j]
+			changed := false
+			walkValues(field.TypeRef, func(node *TypeRef) {
+				if isTimeRef(node) {
+					node.Name = "Date"
+					node.Hint = ""
+					changed = true
+				}
+			})
+			if changed {
+				field.Type = field.TypeRef.String()
+			}
+		}
+	}
+}
+
+// dateRef returns a copy of ref rendering time.Time as Date, like the fields of the types
+// converted by applyDateType
+func dateRef(ref *TypeRef) *TypeRef {
+	clone := ref.Clone()
+	walkValues(clone, func(node *TypeRef) {
+		if isTimeRef(node) {
+			node.Name = "Date"
+			node.Hint = ""
+		}
+	})
+	return clone
+}
+
+// usesDateType checks if the types were collected with the Date mode, in which time.Time
+// fields are rendered as Date
+func usesDateType(types []TypeScriptType) bool {
+	found := false
+	for _, t := range types {
+		for _, field := range t.Fields {
+			walkValues(field.TypeRef, func(node *TypeRef) {
+				if isTimeRef(node) && node.Name == "Date" {
+					found = true
+				}
+			})
+		}
+	}
+	return found
+}
+
+// dateTypeNames returns the names of the types that contain time.Time values, directly or
+// through the types they reference
+func dateTypeNames(types []TypeScriptType) map[string]bool {
+	dateTypes := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, t := range types {
+			if dateTypes[t.Name] {
+				continue
+			}
+			for _, field := range t.Fields {
+				if refHasDates(field.TypeRef, dateTypes) {
+					dateTypes[t.Name] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return dateTypes
+}
+
+// refHasDates checks if a TypeRef contains time.Time values or references a type that does
+func refHasDates(ref *TypeRef, dateTypes map[string]bool) bool {
+	found := false
+	walkValues(ref, func(node *TypeRef) {
+		if isTimeRef(node) || ((node.Kind == KindNamed || node.Kind == KindComposite) && dateTypes[node.Name]) {
+			found = true
+		}
+	})
+	return found
+}
+
+// dateHelperNames returns the names of the parse and serialize helpers generated for a type
+func dateHelperNames(typeName string) (string, string) {
+	return "parse" + typeName, "serialize" + typeName
+}
+
+// writeDateHelpers writes parseX and serializeX functions for every type in types that
+// contains time.Time values. parseX converts the RFC3339 strings of a decoded JSON value to
+// Date objects and serializeX converts them back, walking nested types, arrays, maps and
+// nullable values. Union members are looked up in allTypes.
+func writeDateHelpers(w io.Writer, types, allTypes []TypeScriptType, dateTypes map[string]bool) {
+	var body strings.Builder
+
+	for _, t := range types {
+		if !dateTypes[t.Name] {
+			continue
+		}
+		parseName, serializeName := dateHelperNames(t.Name)
+
+		for _, parse := range []bool{true, false} {
+			name, param, paramType, resultType := serializeName, "value", t.Name, "any"
+			if parse {
+				name, param, paramType, resultType = parseName, "json", "any", t.Name
+			}
+
+			fmt.Fprintf(&body, "export function %s(%s: %s): %s {\n", name, param, paramType, resultType)
+			if t.IsInterface {
+				fmt.Fprintf(&body, "  return {\n    ...%s,\n", param)
+				for _, field := range t.Fields {
+					if !refHasDates(field.TypeRef, dateTypes) {
+						continue
+					}
+					access := tsPropertyAccess(param, field.Name)
+					expr := dateConversion(field.TypeRef, access, parse, allTypes, dateTypes, 0)
+					// Optional fields may be missing from the value
+					if field.Optional && !strings.HasPrefix(expr, access+" == null") {
+						expr = access + " === undefined ? " + access + " : " + expr
+					}
+					fmt.Fprintf(&body, "    %s: %s,\n", tsPropertyKey(field.Name), expr)
+				}
+				fmt.Fprintln(&body, "  };")
+			} else if len(t.Fields) > 0 {
+				fmt.Fprintf(&body, "  return %s;\n", dateConversion(t.Fields[0].TypeRef, param, parse, allTypes, dateTypes, 0))
+			}
+			fmt.Fprintln(&body, "}")
+			fmt.Fprintln(&body)
+		}
+	}
+
+	if body.Len() == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "// Date conversion helpers")
+	fmt.Fprintln(w)
+	if strings.Contains(body.String(), "mapRecord(") {
+		io.WriteString(w, mapRecordHelper+"\n")
+	}
+	io.WriteString(w, body.String())
+}
+
+// dateConversion returns a TypeScript expression converting the value of expr, typed by
+// ref, between its JSON and Date representations. types holds the union members, whose
+// discriminator values select the conversion of a union value.
+func dateConversion(ref *TypeRef, expr string, parse bool, types []TypeScriptType, dateTypes map[string]bool, depth int) string {
+	if !refHasDates(ref, dateTypes) {
+		return expr
+	}
+
+	switch ref.Kind {
+	case KindPrimitive:
+		if parse {
+			return "new Date(" + expr + ")"
+		}
+		return expr + ".toISOString()"
+	case KindNamed:
+		parseName, serializeName := dateHelperNames(ref.Name)
+		if parse {
+			return parseName + "(" + expr + ")"
+		}
+		return serializeName + "(" + expr + ")"
+	case KindComposite:
+		// Convert the base type, then the overridden fields
+		base := expr
+		if dateTypes[ref.Name] {
+			parseName, serializeName := dateHelperNames(ref.Name)
+			if parse {
+				base = parseName + "(" + expr + ")"
+			} else {
+				base = serializeName + "(" + expr + ")"
+			}
+		}
+		var fields []string
+		for _, override := range ref.Overrides {
+			if refHasDates(override.Type, dateTypes) {
+				access := tsPropertyAccess(expr, override.Name)
+				fields = append(fields, tsPropertyKey(override.Name)+": "+dateConversion(override.Type, access, parse, types, dateTypes, depth))
+			}
+		}
+		if len(fields) == 0 {
+			return base
+		}
+		return "{ ..." + base + ", " + strings.Join(fields, ", ") + " }"
+	case KindNullable:
+		// Arrays and maps already check for null
+		if ref.Elem.Kind == KindArray || ref.Elem.Kind == KindMap {
+			return dateConversion(ref.Elem, expr, parse, types, dateTypes, depth)
+		}
+		return expr + " == null ? " + expr + " : " + dateConversion(ref.Elem, expr, parse, types, dateTypes, depth)
+	case KindArray:
+		// Nil slices and maps are encoded as null by encoding/json
+		v := fmt.Sprintf("v%d", depth)
+		return fmt.Sprintf("%s == null ? %s : %s.map((%s: any) => %s)", expr, expr, expr, v, dateConversion(ref.Elem, v, parse, types, dateTypes, depth+1))
+	case KindMap:
+		v := fmt.Sprintf("v%d", depth)
+		return fmt.Sprintf("%s == null ? %s : mapRecord(%s, (%s: any) => %s)", expr, expr, expr, v, dateConversion(ref.Elem, v, parse, types, dateTypes, depth+1))
+	case KindUnion:
+		return unionDateConversion(ref, expr, parse, types, dateTypes, depth)
+	}
+	return expr
+}
+
+// unionDateConversion returns a TypeScript expression converting a value of a union with the
+// helpers of the member selected by the discriminator property. Values of unions without a
+// discriminator cannot be told apart and are returned unchanged.
+func unionDateConversion(ref *TypeRef, expr string, parse bool, types []TypeScriptType, dateTypes map[string]bool, depth int) string {
+	if ref.Discriminator == "" {
+		return expr
+	}
+	result := expr
+	for i := len(ref.Members) - 1; i >= 0; i-- {
+		member := ref.Members[i]
+		if !dateTypes[member.Name] {
+			continue
+		}
+		for _, t := range types {
+			if value, ok := discriminatorValue(t, ref.Discriminator); ok && t.Name == member.Name {
+				check := tsPropertyAccess(expr, ref.Discriminator) + " === " + strconv.Quote(value)
+				result = check + " ? " + dateConversion(member, expr, parse, types, dateTypes, depth) + " : " + result
+			}
+		}
+	}
+	return result
+}
+
+// tsPropertyAccess returns a property access expression, using brackets for names that
+// are not valid identifiers
+func tsPropertyAccess(object, name string) string {
+	if isTSIdentifier(name) {
+		return object + "." + name
+	}
+	return fmt.Sprintf("%s[%q]", object, name)
+}
+
+// tsPropertyKey returns a property key for an object literal or interface, quoting names
+// that are not valid identifiers
+func tsPropertyKey(name string) string {
+	if isTSIdentifier(name) {
+		return name
+	}
+	return fmt.Sprintf("%q", name)
+}
+
+// isTSIdentifier checks if a name is a valid TypeScript identifier
+func isTSIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r > 0x7f {
+			continue
+		}
+		if i > 0 && r >= '0' && r <= '9' {
+			continue
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,374 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ChangeKind classifies a change between two models
+type ChangeKind string
+
+// Kinds of changes between two models
+const (
+	ChangeTypeRemoved        ChangeKind = "type-removed"
+	ChangeTypeAdded          ChangeKind = "type-added"
+	ChangeFieldRemoved       ChangeKind = "field-removed"
+	ChangeFieldAdded         ChangeKind = "field-added"
+	ChangeTypeNarrowed       ChangeKind = "type-narrowed"        // The new type accepts fewer values
+	ChangeTypeWidened        ChangeKind = "type-widened"         // The new type accepts more values
+	ChangeTypeChanged        ChangeKind = "type-changed"         // The types are unrelated
+	ChangeOptionalToRequired ChangeKind = "optional-to-required" // An optional field became required
+	ChangeRequiredToOptional ChangeKind = "required-to-optional" // A required field became optional
+	ChangeNullableAdded      ChangeKind = "nullable-added"
+	ChangeNullableRemoved    ChangeKind = "nullable-removed"
+	ChangeEnumMemberRemoved  ChangeKind = "enum-member-removed"
+	ChangeEnumMemberAdded    ChangeKind = "enum-member-added"
+	ChangeEndpointRemoved    ChangeKind = "endpoint-removed"
+	ChangeEndpointAdded      ChangeKind = "endpoint-added"
+)
+
+// Change is a difference between two models. Breaking changes can break clients built
+// against the old model.
+type Change struct {
+	Kind     ChangeKind `json:"kind"`
+	Breaking bool       `json:"breaking"`
+	Type     string     `json:"type,omitempty"`     // Name of the changed type
+	Field    string     `json:"field,omitempty"`    // Name of the changed field
+	Endpoint string     `json:"endpoint,omitempty"` // Changed endpoint, e.g. GET /users/{id}
+	Old      string     `json:"old,omitempty"`      // Old type or enum member
+	New      string     `json:"new,omitempty"`      // New type or enum member
+}
+
+// String describes the change on a single line
+func (c Change) String() string {
+	var subject string
+	switch {
+	case c.Endpoint != "":
+		subject = c.Endpoint
+	case c.Field != "":
+		subject = c.Type + "." + c.Field
+	default:
+		subject = c.Type
+	}
+	switch {
+	case c.Old != "" && c.New != "":
+		return fmt.Sprintf("%s: %s (%s -> %s)", c.Kind, subject, c.Old, c.New)
+	case c.Old != "":
+		return fmt.Sprintf("%s: %s (%s)", c.Kind, subject, c.Old)
+	case c.New != "":
+		return fmt.Sprintf("%s: %s (%s)", c.Kind, subject, c.New)
+	}
+	return fmt.Sprintf("%s: %s", c.Kind, subject)
+}
+
+// HasBreakingChanges checks if any of the changes is breaking
+func HasBreakingChanges(changes []Change) bool {
+	for _, change := range changes {
+		if change.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// typeDirection records whether a type is sent to clients, received from them, or both.
+// Types without endpoint information are assumed to be used in both directions.
+type typeDirection struct {
+	response bool
+	request  bool
+}
+
+// DiffModels compares two models and returns the changes to their types and endpoints, in
+// the order of the old model followed by the additions of the new one. Whether a change is
+// breaking depends on how the old type is used: changes that accept more values break the
+// clients reading responses, and changes that accept fewer values break the clients sending
+// requests.
+func DiffModels(oldModel, newModel *Model) []Change {
+	var changes []Change
+
+	responses, requests := typeUsage(oldModel.Types)
+	directions := make(map[string]typeDirection)
+	for _, t := range oldModel.Types {
+		direction := typeDirection{response: responses[t.Name], request: requests[t.Name]}
+		if !direction.response && !direction.request {
+			direction = typeDirection{response: true, request: true}
+		}
+		directions[t.Name] = direction
+	}
+
+	d := &modelDiff{
+		oldSchemas: newSchemaBuilder(oldModel.Types, "#/$defs/", false),
+		newSchemas: newSchemaBuilder(newModel.Types, "#/$defs/", false),
+	}
+
+	newTypes := make(map[string]*TypeScriptType)
+	for i := range newModel.Types {
+		newTypes[newModel.Types[i].Name] = &newModel.Types[i]
+	}
+	oldTypes := make(map[string]bool)
+	for _, t := range oldModel.Types {
+		oldTypes[t.Name] = true
+		newType := newTypes[t.Name]
+		if newType == nil {
+			changes = append(changes, Change{Kind: ChangeTypeRemoved, Breaking: true, Type: t.Name})
+			continue
+		}
+		changes = append(changes, d.typeChanges(t, *newType, directions[t.Name])...)
+	}
+	for _, t := range newModel.Types {
+		if !oldTypes[t.Name] {
+			changes = append(changes, Change{Kind: ChangeTypeAdded, Type: t.Name})
+		}
+	}
+
+	oldEndpoints := operationKeys(oldModel.Operations)
+	newEndpoints := operationKeys(newModel.Operations)
+	for _, key := range oldEndpoints {
+		if !containsString(newEndpoints, key) {
+			changes = append(changes, Change{Kind: ChangeEndpointRemoved, Breaking: true, Endpoint: key})
+		}
+	}
+	for _, key := range newEndpoints {
+		if !containsString(oldEndpoints, key) {
+			changes = append(changes, Change{Kind: ChangeEndpointAdded, Endpoint: key})
+		}
+	}
+	return changes
+}
+
+// modelDiff compares the fields of two models
+type modelDiff struct {
+	oldSchemas *schemaBuilder // Schemas of the old fields, holding their enum members
+	newSchemas *schemaBuilder
+}
+
+// typeChanges returns the changes between two versions of a type. Type aliases are compared
+// through their single "value" field, reported without a field name.
+func (d *modelDiff) typeChanges(oldType, newType TypeScriptType, direction typeDirection) []Change {
+	if !oldType.IsInterface || !newType.IsInterface {
+		if oldType.IsInterface != newType.IsInterface || len(oldType.Fields) == 0 || len(newType.Fields) == 0 {
+			return []Change{{Kind: ChangeTypeChanged, Breaking: true, Type: oldType.Name}}
+		}
+		return d.fieldChanges(oldType.Name, "", oldType.Fields[0], newType.Fields[0], direction)
+	}
+
+	var changes []Change
+	newFields := make(map[string]TypeScriptField)
+	for _, field := range newType.Fields {
+		newFields[field.Name] = field
+	}
+	oldFields := make(map[string]bool)
+	for _, field := range oldType.Fields {
+		oldFields[field.Name] = true
+		newField, exists := newFields[field.Name]
+		if !exists {
+			changes = append(changes, Change{Kind: ChangeFieldRemoved, Breaking: direction.response, Type: oldType.Name, Field: field.Name})
+			continue
+		}
+		changes = append(changes, d.fieldChanges(oldType.Name, field.Name, field, newField, direction)...)
+	}
+	for _, field := range newType.Fields {
+		if !oldFields[field.Name] {
+			// Clients sending the type must provide new required fields
+			breaking := direction.request && !field.Optional
+			changes = append(changes, Change{Kind: ChangeFieldAdded, Breaking: breaking, Type: oldType.Name, Field: field.Name, New: field.Type})
+		}
+	}
+	return changes
+}
+
+// fieldChanges returns the changes between two versions of a field
+func (d *modelDiff) fieldChanges(typeName, fieldName string, oldField, newField TypeScriptField, direction typeDirection) []Change {
+	var changes []Change
+	add := func(kind ChangeKind, breaking bool, oldValue, newValue string) {
+		changes = append(changes, Change{Kind: kind, Breaking: breaking, Type: typeName, Field: fieldName, Old: oldValue, New: newValue})
+	}
+
+	if oldField.Optional && !newField.Optional {
+		add(ChangeOptionalToRequired, direction.request, "", "")
+	} else if !oldField.Optional && newField.Optional {
+		add(ChangeRequiredToOptional, direction.response, "", "")
+	}
+
+	oldRef, oldNullable := splitNullable(oldField.TypeRef)
+	newRef, newNullable := splitNullable(newField.TypeRef)
+	if !oldNullable && newNullable {
+		add(ChangeNullableAdded, direction.response, "", "")
+	} else if oldNullable && !newNullable {
+		add(ChangeNullableRemoved, direction.request, "", "")
+	}
+
+	// Enum members declared with literal types or oneof rules
+	oldMembers := enumMembers(d.oldSchemas.fieldSchema(oldField))
+	newMembers := enumMembers(d.newSchemas.fieldSchema(newField))
+	if oldMembers != nil && newMembers != nil {
+		for _, member := range oldMembers {
+			if !containsString(newMembers, member) {
+				add(ChangeEnumMemberRemoved, direction.request, member, "")
+			}
+		}
+		for _, member := range newMembers {
+			if !containsString(oldMembers, member) {
+				add(ChangeEnumMemberAdded, direction.response, "", member)
+			}
+		}
+		return changes
+	}
+
+	oldType, newType := oldRef.String(), newRef.String()
+	if oldType == newType {
+		return changes
+	}
+	oldSet, newSet := primitiveMembers(oldRef), primitiveMembers(newRef)
+	switch {
+	case oldSet != nil && newSet != nil && isSubset(newSet, oldSet):
+		add(ChangeTypeNarrowed, direction.request, oldType, newType)
+	case oldSet != nil && newSet != nil && isSubset(oldSet, newSet):
+		add(ChangeTypeWidened, direction.response, oldType, newType)
+	case oldType == "any":
+		add(ChangeTypeNarrowed, direction.request, oldType, newType)
+	case newType == "any":
+		add(ChangeTypeWidened, direction.response, oldType, newType)
+	default:
+		add(ChangeTypeChanged, true, oldType, newType)
+	}
+	return changes
+}
+
+// splitNullable returns the non-null part of a TypeRef and whether it accepts null
+func splitNullable(ref *TypeRef) (*TypeRef, bool) {
+	if ref == nil {
+		return primitiveRef("any"), false
+	}
+	if ref.Kind == KindNullable {
+		return ref.Elem, true
+	}
+	if ref.Kind == KindPrimitive && strings.Contains(ref.Name, " | ") {
+		var members []string
+		nullable := false
+		for _, name := range strings.Split(ref.Name, " | ") {
+			if name == "null" {
+				nullable = true
+			} else {
+				members = append(members, name)
+			}
+		}
+		if nullable {
+			clone := *ref
+			clone.Name = strings.Join(members, " | ")
+			return &clone, true
+		}
+	}
+	return ref, false
+}
+
+// primitiveMembers returns the members of a primitive union, or nil for other TypeRefs
+func primitiveMembers(ref *TypeRef) []string {
+	if ref.Kind != KindPrimitive || ref.Name == "any" || ref.Name == "unknown" {
+		return nil
+	}
+	return strings.Split(ref.Name, " | ")
+}
+
+// enumMembers returns the enum or const values of a field schema as JSON literals, or nil
+// when the field is not an enum
+func enumMembers(schema *jsonObject) []string {
+	if value, ok := schema.Get("const"); ok {
+		return []string{jsonLiteral(value)}
+	}
+	values, ok := schema.Get("enum")
+	if !ok {
+		return nil
+	}
+	var members []string
+	switch list := values.(type) {
+	case []any:
+		for _, value := range list {
+			members = append(members, jsonLiteral(value))
+		}
+	case []string:
+		for _, value := range list {
+			members = append(members, jsonLiteral(value))
+		}
+	}
+	return members
+}
+
+// isSubset checks if every member of subset is in set
+func isSubset(subset, set []string) bool {
+	for _, member := range subset {
+		if !containsString(set, member) {
+			return false
+		}
+	}
+	return true
+}
+
+// operationKeys returns the sorted "METHOD /path" keys of the operations
+func operationKeys(operations []Operation) []string {
+	var keys []string
+	for _, op := range operations {
+		key := strings.ToUpper(op.Method) + " " + op.Path
+		if !containsString(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// WriteDiffReport writes a human-readable report of the changes, breaking changes first
+func WriteDiffReport(w io.Writer, changes []Change) error {
+	var breaking, other []Change
+	for _, change := range changes {
+		if change.Breaking {
+			breaking = append(breaking, change)
+		} else {
+			other = append(other, change)
+		}
+	}
+
+	var b strings.Builder
+	if len(changes) == 0 {
+		b.WriteString("No changes\n")
+	}
+	for _, group := range []struct {
+		title   string
+		changes []Change
+	}{{"Breaking changes", breaking}, {"Non-breaking changes", other}} {
+		if len(group.changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "%s (%d):\n", group.title, len(group.changes))
+		for _, change := range group.changes {
+			fmt.Fprintf(&b, "  - %s\n", change)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteDiffJSON writes the changes as a JSON document with the number of breaking changes
+func WriteDiffJSON(w io.Writer, changes []Change) error {
+	breaking := 0
+	for _, change := range changes {
+		if change.Breaking {
+			breaking++
+		}
+	}
+	if changes == nil {
+		changes = []Change{}
+	}
+	data, err := json.MarshalIndent(struct {
+		Breaking int      `json:"breaking"`
+		Changes  []Change `json:"changes"`
+	}{breaking, changes}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding changes: %v", err)
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
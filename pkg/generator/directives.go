@@ -0,0 +1,216 @@
+package generator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// outDirective declares an output of a package, written relative to the package directory:
+// //tsgen:out ../../web/src/types/models.ts, or //tsgen:out openapi=../../docs/api.yaml
+// for another renderer than the TypeScript types
+const outDirective = "//tsgen:out"
+
+// outRendererRegex matches the renderer name prefix of a //tsgen:out value
+var outRendererRegex = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_-]*)=(.+)$`)
+
+// OutputTarget is an output declared with a //tsgen:out directive
+type OutputTarget struct {
+	Renderer string // Renderer name, "ts" for the TypeScript types
+	Path     string // Output file
+}
+
+// outputDirective is a //tsgen:out directive with its output file as an absolute path
+type outputDirective struct {
+	Renderer string
+	Path     string
+}
+
+// targetCache holds the models of the packages declaring an output, keyed by the slash-separated
+// absolute package directory
+type targetCache struct {
+	Version  int               `json:"version"`
+	Output   string            `json:"output"`
+	Packages map[string]*Model `json:"packages"`
+}
+
+// DefaultCacheDir returns the directory of the caches of the outputs shared by several
+// packages, go-ts-generator in the user cache directory
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "go-ts-generator"), nil
+}
+
+// targetCachePath returns the cache file of an output in cacheDir, named after the output
+// file and a hash of its absolute path, e.g. models.ts-1f2e3d4c5b6a7988.json
+func targetCachePath(cacheDir, output string) string {
+	sum := sha256.Sum256([]byte(output))
+	return filepath.Join(cacheDir, fmt.Sprintf("%s-%x.json", filepath.Base(output), sum[:8]))
+}
+
+// PackageTargets returns the outputs declared with //tsgen:out directives by the Go files of
+// package pkgName in dir, such as $GOPACKAGE under go generate, or by every package in dir
+// when pkgName is empty. The paths are relative to dir.
+func PackageTargets(dir, pkgName string) ([]OutputTarget, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	directives, err := packageDirectives(absDir, pkgName)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []OutputTarget
+	for _, directive := range directives {
+		targets = append(targets, OutputTarget{Renderer: directive.Renderer, Path: relativePath(absDir, directive.Path)})
+	}
+	return targets, nil
+}
+
+// MergeTargetModel records the model of the package in dir in the cache of a target declared
+// by the package, a file in cacheDir such as DefaultCacheDir, and returns the models of every
+// package in the cache merged in path order. Packages whose directives no longer declare the
+// output are removed from the cache, so each package declaring the same output only collects
+// its own directory and the output still holds the types of all of them.
+func MergeTargetModel(cacheDir, dir string, target OutputTarget, model *Model) (*Model, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	output := target.Path
+	if !filepath.IsAbs(output) {
+		output = filepath.Join(absDir, output)
+	}
+	output = filepath.Clean(output)
+	cachePath := targetCachePath(cacheDir, output)
+
+	// Caches of another IR version are rebuilt
+	cache := targetCache{Version: IRVersion, Output: output, Packages: make(map[string]*Model)}
+	if data, err := os.ReadFile(cachePath); err == nil {
+		var saved targetCache
+		if json.Unmarshal(data, &saved) == nil && saved.Version == IRVersion && saved.Output == output && saved.Packages != nil {
+			cache = saved
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading %s: %v", cachePath, err)
+	}
+
+	key := filepath.ToSlash(absDir)
+	cache.Packages[key] = model
+	declared := outputDirective{Renderer: target.Renderer, Path: output}
+	for other := range cache.Packages {
+		if other == key {
+			continue
+		}
+		// Invalid directives are reported when their own package is generated
+		directives, err := packageDirectives(filepath.FromSlash(other), "")
+		if err != nil || !slices.Contains(directives, declared) {
+			delete(cache.Packages, other)
+		}
+	}
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error encoding %s: %v", cachePath, err)
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating %s: %v", cacheDir, err)
+	}
+	if err := os.WriteFile(cachePath, append(data, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("error writing %s: %v", cachePath, err)
+	}
+
+	keys := make([]string, 0, len(cache.Packages))
+	for k := range cache.Packages {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	models := make([]*Model, len(keys))
+	for i, k := range keys {
+		models[i] = cache.Packages[k]
+	}
+	return MergeModels(models...), nil
+}
+
+// packageDirectives reads the //tsgen:out directives of the Go files of package pkgName in the
+// directory, or of every package when pkgName is empty
+func packageDirectives(dir, pkgName string) ([]outputDirective, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var directives []outputDirective
+	seen := make(map[outputDirective]bool)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Contains(src, []byte(outDirective)) {
+			continue
+		}
+
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing file %s: %v", path, err)
+		}
+		// Files of other packages, such as generators built with //go:build ignore
+		if pkgName != "" && file.Name.Name != pkgName {
+			continue
+		}
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				value, ok := strings.CutPrefix(comment.Text, outDirective)
+				if !ok || (value != "" && value[0] != ' ' && value[0] != '\t') {
+					continue
+				}
+				value = strings.TrimSpace(value)
+				if value == "" {
+					return nil, fmt.Errorf("%s: %s needs an output file", fset.Position(comment.Pos()), outDirective)
+				}
+
+				directive := outputDirective{Renderer: "ts"}
+				if match := outRendererRegex.FindStringSubmatch(value); match != nil {
+					directive.Renderer, value = match[1], match[2]
+				}
+				if !filepath.IsAbs(value) {
+					value = filepath.Join(dir, filepath.FromSlash(value))
+				}
+				directive.Path = filepath.Clean(value)
+				if !seen[directive] {
+					seen[directive] = true
+					directives = append(directives, directive)
+				}
+			}
+		}
+	}
+	return directives, nil
+}
+
+// relativePath returns path relative to dir when possible
+func relativePath(dir, path string) string {
+	if rel, err := filepath.Rel(dir, path); err == nil {
+		return rel
+	}
+	return path
+}
@@ -0,0 +1,549 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// routeMethods maps the registration methods of gin, echo and chi routers to HTTP methods
+var routeMethods = map[string]string{
+	// gin and echo
+	"GET":     "get",
+	"POST":    "post",
+	"PUT":     "put",
+	"PATCH":   "patch",
+	"DELETE":  "delete",
+	"HEAD":    "head",
+	"OPTIONS": "options",
+	// chi
+	"Get":     "get",
+	"Post":    "post",
+	"Put":     "put",
+	"Patch":   "patch",
+	"Delete":  "delete",
+	"Head":    "head",
+	"Options": "options",
+	"Connect": "connect",
+	"Trace":   "trace",
+}
+
+// routeHandleMethods lists the registration methods taking the HTTP method as first argument:
+// gin Handle, echo Add and chi Method/MethodFunc
+var routeHandleMethods = map[string]bool{
+	"Handle":     true,
+	"Add":        true,
+	"Method":     true,
+	"MethodFunc": true,
+}
+
+var (
+	// Go 1.22 net/http patterns: [METHOD ][HOST]/[PATH]
+	muxPatternRegex = regexp.MustCompile(`^(?:([A-Z]+)\s+)?[^/\s]*(/.*)$`)
+	// Path wildcards of gin and echo (*path), net/http ({path...}, {$}) and chi ({id:[0-9]+})
+	wildcardRegex     = regexp.MustCompile(`\*(\w+)`)
+	muxWildcardRegex  = regexp.MustCompile(`\{(\w+)\.\.\.\}`)
+	chiWildcardRegex  = regexp.MustCompile(`\{(\w+):[^}]*\}`)
+	muxEndAnchorRegex = regexp.MustCompile(`\{\$\}$`)
+)
+
+// routeIndex holds the declarations needed to resolve routes across source directories
+type routeIndex struct {
+	funcs     map[string]*ast.FuncDecl // Functions by name
+	methods   map[string]*ast.FuncDecl // Methods by name, the first declaration wins
+	constants map[string]string        // String constants by name
+	called    map[*ast.FuncDecl]bool   // Functions called with a router argument
+	active    map[*ast.FuncDecl]bool   // Functions being analysed, to stop recursive calls
+}
+
+// DiscoverRoutes statically analyses router setup code in the source directories and returns
+// an operation for every registered route. gin and echo (r.GET, e.POST, Group), chi (r.Get,
+// Route, Group, With) and net/http (HandleFunc with Go 1.22 method patterns) registrations
+// are recognised. The request and response types of each route are inferred from the bind
+// and write calls in the body of its handler.
+func DiscoverRoutes(sourceDirs []string) ([]Operation, error) {
+	return discoverRoutes(context.Background(), sourceDirs)
+}
+
+// discoverRoutes discovers the routes of the source directories, stopping when the context
+// is cancelled
+func discoverRoutes(ctx context.Context, sourceDirs []string) ([]Operation, error) {
+	index := &routeIndex{
+		funcs:     make(map[string]*ast.FuncDecl),
+		methods:   make(map[string]*ast.FuncDecl),
+		constants: make(map[string]string),
+		called:    make(map[*ast.FuncDecl]bool),
+		active:    make(map[*ast.FuncDecl]bool),
+	}
+
+	var files []*ast.File
+	for _, sourceDir := range sourceDirs {
+		err := walkContext(ctx, sourceDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			// Process only Go files
+			if info.IsDir() || !strings.HasSuffix(path, ".go") {
+				return nil
+			}
+
+			fset := token.NewFileSet()
+			node, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+			if err != nil {
+				fmt.Printf("Error parsing file %s: %v\n", path, err)
+				return nil
+			}
+			files = append(files, node)
+			index.add(node)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error walking directory %s for routes: %w", sourceDir, err)
+		}
+	}
+
+	type declRoutes struct {
+		decl       *ast.FuncDecl
+		operations []Operation
+	}
+	var routes []declRoutes
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Body != nil {
+				index.active[funcDecl] = true
+				routes = append(routes, declRoutes{funcDecl, index.routes(funcDecl.Body, make(map[string]string))})
+				delete(index.active, funcDecl)
+			}
+		}
+	}
+
+	// The routes of functions called with a router are registered under the prefix of the
+	// router passed by their callers
+	var operations []Operation
+	for _, r := range routes {
+		if !index.called[r.decl] {
+			operations = append(operations, r.operations...)
+		}
+	}
+	return operations, nil
+}
+
+// add indexes the functions, methods and string constants of a file
+func (idx *routeIndex) add(file *ast.File) {
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				idx.funcs[d.Name.Name] = d
+			} else if _, exists := idx.methods[d.Name.Name]; !exists {
+				idx.methods[d.Name.Name] = d
+			}
+		case *ast.GenDecl:
+			if d.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range d.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for i, name := range valueSpec.Names {
+					if i < len(valueSpec.Values) {
+						if value, ok := idx.stringValue(valueSpec.Values[i]); ok {
+							idx.constants[name.Name] = value
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// routes returns the operations registered in a function body. prefixes maps router
+// variables to the path prefix of their group.
+func (idx *routeIndex) routes(body *ast.BlockStmt, prefixes map[string]string) []Operation {
+	var operations []Operation
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			// api := r.Group("/api")
+			for i, lhs := range node.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok || i >= len(node.Rhs) {
+					continue
+				}
+				if prefix, ok := idx.groupPrefix(node.Rhs[i], prefixes); ok {
+					prefixes[ident.Name] = prefix
+				}
+			}
+		case *ast.CallExpr:
+			// registerUsers(api) registers the routes of a function under the prefix of api
+			if decl, inner, ok := idx.routerCall(node, prefixes); ok {
+				idx.called[decl] = true
+				idx.active[decl] = true
+				operations = append(operations, idx.routes(decl.Body, inner)...)
+				delete(idx.active, decl)
+				return false
+			}
+
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			// chi: r.Route("/api", func(r chi.Router) { ... }) and r.Group(func(r chi.Router) { ... })
+			if fn, prefix, ok := idx.subrouter(node, sel, prefixes); ok {
+				inner := make(map[string]string, len(prefixes))
+				for name, value := range prefixes {
+					inner[name] = value
+				}
+				if fn.Type.Params != nil {
+					for _, param := range fn.Type.Params.List {
+						for _, name := range param.Names {
+							inner[name.Name] = prefix
+						}
+					}
+				}
+				operations = append(operations, idx.routes(fn.Body, inner)...)
+				return false
+			}
+
+			if op, ok := idx.registration(node, sel, prefixes); ok {
+				operations = append(operations, op)
+			}
+		}
+		return true
+	})
+
+	return operations
+}
+
+// groupPrefix returns the path prefix of a router expression: a router variable, a group
+// (r.Group("/api")) or a router with middleware (r.With(mw))
+func (idx *routeIndex) groupPrefix(expr ast.Expr, prefixes map[string]string) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		prefix, ok := prefixes[e.Name]
+		return prefix, ok
+	case *ast.CallExpr:
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return "", false
+		}
+		switch sel.Sel.Name {
+		case "Group":
+			if len(e.Args) == 0 {
+				return "", false
+			}
+			path, ok := idx.stringValue(e.Args[0])
+			if !ok {
+				return "", false
+			}
+			parent, _ := idx.groupPrefix(sel.X, prefixes)
+			return joinRoutePath(parent, path), true
+		case "With", "Use":
+			prefix, _ := idx.groupPrefix(sel.X, prefixes)
+			return prefix, true
+		}
+	}
+	return "", false
+}
+
+// routerCall returns the declaration of a package-level function called with router
+// arguments, and the prefixes of its parameters receiving them
+func (idx *routeIndex) routerCall(call *ast.CallExpr, prefixes map[string]string) (*ast.FuncDecl, map[string]string, bool) {
+	var decl *ast.FuncDecl
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		decl = idx.funcs[fun.Name]
+	case *ast.SelectorExpr:
+		// Package-qualified functions (routes.RegisterUsers(api)), not methods of routers
+		if pkg, ok := fun.X.(*ast.Ident); ok {
+			if _, isRouter := prefixes[pkg.Name]; !isRouter {
+				decl = idx.funcs[fun.Sel.Name]
+			}
+		}
+	}
+	if decl == nil || decl.Body == nil || decl.Type.Params == nil || idx.active[decl] {
+		return nil, nil, false
+	}
+
+	inner := make(map[string]string)
+	arg := 0
+	for _, param := range decl.Type.Params.List {
+		names := param.Names
+		if len(names) == 0 {
+			// Unnamed parameters take an argument without a name to bind
+			arg++
+			continue
+		}
+		for _, name := range names {
+			if arg < len(call.Args) {
+				if prefix, ok := idx.groupPrefix(call.Args[arg], prefixes); ok {
+					inner[name.Name] = prefix
+				}
+			}
+			arg++
+		}
+	}
+	if len(inner) == 0 {
+		return nil, nil, false
+	}
+	return decl, inner, true
+}
+
+// subrouter returns the function literal and path prefix of chi Route and Group calls
+func (idx *routeIndex) subrouter(call *ast.CallExpr, sel *ast.SelectorExpr, prefixes map[string]string) (*ast.FuncLit, string, bool) {
+	if len(call.Args) == 0 {
+		return nil, "", false
+	}
+	fn, ok := call.Args[len(call.Args)-1].(*ast.FuncLit)
+	if !ok {
+		return nil, "", false
+	}
+	parent, _ := idx.groupPrefix(sel.X, prefixes)
+
+	switch {
+	case sel.Sel.Name == "Route" && len(call.Args) == 2:
+		path, ok := idx.stringValue(call.Args[0])
+		if !ok {
+			return nil, "", false
+		}
+		return fn, joinRoutePath(parent, path), true
+	case sel.Sel.Name == "Group" && len(call.Args) == 1:
+		return fn, parent, true
+	}
+	return nil, "", false
+}
+
+// registration returns the operation registered by a router call
+func (idx *routeIndex) registration(call *ast.CallExpr, sel *ast.SelectorExpr, prefixes map[string]string) (Operation, bool) {
+	name := sel.Sel.Name
+	var method, path string
+	var handler ast.Expr
+
+	switch {
+	case routeMethods[name] != "" && len(call.Args) >= 2:
+		// r.GET("/users/:id", middleware, h.GetUser)
+		p, ok := idx.stringValue(call.Args[0])
+		if !ok {
+			return Operation{}, false
+		}
+		method, path, handler = routeMethods[name], p, call.Args[len(call.Args)-1]
+	case routeHandleMethods[name] && len(call.Args) >= 3:
+		// r.Handle("GET", "/users/:id", h.GetUser)
+		m, ok1 := idx.stringValue(call.Args[0])
+		p, ok2 := idx.stringValue(call.Args[1])
+		if !ok1 || !ok2 {
+			return Operation{}, false
+		}
+		method, path, handler = strings.ToLower(m), p, call.Args[len(call.Args)-1]
+	case (name == "HandleFunc" || name == "Handle") && len(call.Args) == 2:
+		// http.HandleFunc("GET /users/{id}", getUser)
+		pattern, ok := idx.stringValue(call.Args[0])
+		if !ok {
+			return Operation{}, false
+		}
+		match := muxPatternRegex.FindStringSubmatch(pattern)
+		// Patterns without a method match every method and cannot be described as one operation
+		if match == nil || match[1] == "" {
+			return Operation{}, false
+		}
+		method, path, handler = strings.ToLower(match[1]), match[2], call.Args[1]
+	default:
+		return Operation{}, false
+	}
+
+	if !strings.HasPrefix(path, "/") && path != "" {
+		return Operation{}, false
+	}
+	if path == "" && sel.X != nil {
+		if _, isRouter := idx.groupPrefix(sel.X, prefixes); !isRouter {
+			return Operation{}, false
+		}
+	}
+
+	prefix, _ := idx.groupPrefix(sel.X, prefixes)
+	op := Operation{
+		Method: method,
+		Path:   routePath(joinRoutePath(prefix, path)),
+	}
+	idx.describeHandler(&op, handler)
+	return op, true
+}
+
+// describeHandler resolves the handler of an operation and records its name, summary and
+// the types it binds and writes
+func (idx *routeIndex) describeHandler(op *Operation, handler ast.Expr) {
+	var params *ast.FieldList
+	var body *ast.BlockStmt
+
+	switch h := handler.(type) {
+	case *ast.FuncLit:
+		params, body = h.Type.Params, h.Body
+	case *ast.Ident, *ast.SelectorExpr:
+		if decl := idx.lookup(h); decl != nil {
+			op.Handler = decl.Name.Name
+			op.Summary = docSummary(decl.Doc)
+			params, body = decl.Type.Params, decl.Body
+		}
+	case *ast.CallExpr:
+		// http.HandlerFunc(getUser)
+		if len(h.Args) == 1 {
+			if sel, ok := h.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "HandlerFunc" {
+				idx.describeHandler(op, h.Args[0])
+				return
+			}
+		}
+		// Handler factories: h.GetUser() returning a function literal
+		if decl := idx.lookup(h.Fun); decl != nil && decl.Body != nil {
+			op.Handler = decl.Name.Name
+			op.Summary = docSummary(decl.Doc)
+			ast.Inspect(decl.Body, func(n ast.Node) bool {
+				if ret, ok := n.(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+					if fn, ok := ret.Results[0].(*ast.FuncLit); ok {
+						params, body = fn.Type.Params, fn.Body
+						return false
+					}
+				}
+				return true
+			})
+		}
+	}
+	if body == nil {
+		return
+	}
+
+	info := analyzeHandler(params, body)
+	for _, req := range info.Requests {
+		in := "body"
+		switch {
+		case strings.Contains(req.Method, "Query"):
+			in = "query"
+		case strings.Contains(req.Method, "Uri"), strings.Contains(req.Method, "Header"):
+			// Path and header bindings are described by the path and header parameters
+			continue
+		case (req.Method == "Bind" || req.Method == "ShouldBind") && (op.Method == "get" || op.Method == "head" || op.Method == "delete"):
+			// Bind reads the query string of requests without a body
+			in = "query"
+		}
+		op.Parameters = append(op.Parameters, Parameter{Name: in, In: in, Type: req.typeRef(), Required: true})
+	}
+	for _, resp := range info.Responses {
+		status := "200"
+		if resp.Status != 0 {
+			status = strconv.Itoa(resp.Status)
+		}
+		op.Responses = append(op.Responses, Response{Status: status, Type: resp.typeRef()})
+	}
+}
+
+// lookup returns the declaration of a handler referenced by name (getUser, handlers.GetUser)
+// or as a method value (h.GetUser)
+func (idx *routeIndex) lookup(expr ast.Expr) *ast.FuncDecl {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return idx.funcs[e.Name]
+	case *ast.SelectorExpr:
+		// Package-qualified functions and method values are resolved by name
+		if decl, ok := idx.funcs[e.Sel.Name]; ok {
+			if _, isPackage := e.X.(*ast.Ident); isPackage {
+				return decl
+			}
+		}
+		return idx.methods[e.Sel.Name]
+	}
+	return nil
+}
+
+// stringValue evaluates string literals, constants and their concatenations
+func (idx *routeIndex) stringValue(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(e.Value)
+		return value, err == nil
+	case *ast.Ident:
+		value, ok := idx.constants[e.Name]
+		return value, ok
+	case *ast.SelectorExpr:
+		value, ok := idx.constants[e.Sel.Name]
+		return value, ok
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		left, ok1 := idx.stringValue(e.X)
+		right, ok2 := idx.stringValue(e.Y)
+		return left + right, ok1 && ok2
+	case *ast.ParenExpr:
+		return idx.stringValue(e.X)
+	}
+	return "", false
+}
+
+// typeRef returns the TypeRef of a type observed in a handler body
+func (ht handlerType) typeRef() *TypeRef {
+	ref := namedRef(ht.Name)
+	if ht.IsArray {
+		return &TypeRef{Kind: KindArray, Elem: ref}
+	}
+	return ref
+}
+
+// joinRoutePath joins a group prefix and a route path
+func joinRoutePath(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+	if path == "" || path == "/" {
+		return strings.TrimSuffix(prefix, "/") + path
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// routePath converts the path syntax of the supported routers to {param} placeholders
+// e.g. /files/*path -> /files/{path}, /users/{id:[0-9]+} -> /users/{id}
+func routePath(path string) string {
+	path = muxEndAnchorRegex.ReplaceAllString(path, "")
+	path = muxWildcardRegex.ReplaceAllString(path, "{$1}")
+	path = chiWildcardRegex.ReplaceAllString(path, "{$1}")
+	path = wildcardRegex.ReplaceAllString(path, "{$1}")
+	return normalizePath(path)
+}
+
+// docSummary returns the first line of a doc comment
+func docSummary(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(doc.Text()), "\n")
+	return line
+}
+
+// mergeOperations appends the discovered operations whose method and path are not already
+// described by the annotated operations
+func mergeOperations(annotated, discovered []Operation) []Operation {
+	seen := make(map[string]bool)
+	for _, op := range annotated {
+		seen[op.Method+" "+op.Path] = true
+	}
+	for _, op := range discovered {
+		key := op.Method + " " + op.Path
+		if !seen[key] {
+			seen[key] = true
+			annotated = append(annotated, op)
+		}
+	}
+	return annotated
+}
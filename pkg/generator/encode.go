@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// jsonObject is a JSON object that keeps its keys in insertion order, so that generated
+// documents are stable and read naturally (e.g. type before properties)
+type jsonObject struct {
+	keys   []string
+	values map[string]any
+}
+
+// newJSONObject creates an empty jsonObject
+func newJSONObject() *jsonObject {
+	return &jsonObject{values: make(map[string]any)}
+}
+
+// Set sets the value of a key, keeping the position of existing keys
+func (o *jsonObject) Set(key string, value any) *jsonObject {
+	if _, exists := o.values[key]; !exists {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+	return o
+}
+
+// Get returns the value of a key
+func (o *jsonObject) Get(key string) (any, bool) {
+	value, ok := o.values[key]
+	return value, ok
+}
+
+// Len returns the number of keys
+func (o *jsonObject) Len() int {
+	return len(o.keys)
+}
+
+// MarshalJSON encodes the object with its keys in insertion order
+func (o *jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		keyJSON, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		valueJSON, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(keyJSON)
+		buf.WriteByte(':')
+		buf.Write(valueJSON)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// encodeYAML encodes a document made of jsonObjects, slices and scalars as block-style YAML
+func encodeYAML(value any) []byte {
+	var buf bytes.Buffer
+	for _, line := range yamlLines(value) {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// yamlLines returns the lines of a YAML block node, without indentation
+func yamlLines(value any) []string {
+	var lines []string
+	switch v := value.(type) {
+	case *jsonObject:
+		for _, key := range v.keys {
+			child := v.values[key]
+			if inline, ok := yamlInline(child); ok {
+				lines = append(lines, yamlString(key)+": "+inline)
+				continue
+			}
+			lines = append(lines, yamlString(key)+":")
+			for _, line := range yamlLines(child) {
+				lines = append(lines, "  "+line)
+			}
+		}
+	default:
+		for _, item := range yamlItems(value) {
+			if inline, ok := yamlInline(item); ok {
+				lines = append(lines, "- "+inline)
+				continue
+			}
+			for i, line := range yamlLines(item) {
+				if i == 0 {
+					lines = append(lines, "- "+line)
+				} else {
+					lines = append(lines, "  "+line)
+				}
+			}
+		}
+	}
+	return lines
+}
+
+// yamlItems returns the items of a slice value
+func yamlItems(value any) []any {
+	switch v := value.(type) {
+	case []any:
+		return v
+	case []string:
+		items := make([]any, len(v))
+		for i, s := range v {
+			items[i] = s
+		}
+		return items
+	}
+	return nil
+}
+
+// yamlInline returns the inline representation of scalars and empty collections
+func yamlInline(value any) (string, bool) {
+	switch v := value.(type) {
+	case *jsonObject:
+		if v.Len() == 0 {
+			return "{}", true
+		}
+		return "", false
+	case []any, []string:
+		if len(yamlItems(v)) == 0 {
+			return "[]", true
+		}
+		return "", false
+	case string:
+		return yamlString(v), true
+	case nil:
+		return "null", true
+	default:
+		// Numbers and booleans are encoded as in JSON
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "null", true
+		}
+		return string(data), true
+	}
+}
+
+// yamlPlainRegex matches strings that can be written as plain YAML scalars
+var yamlPlainRegex = regexp.MustCompile(`^[A-Za-z_/$][A-Za-z0-9_./{}$ -]*$`)
+
+// yamlString returns a YAML scalar for a string, using a double-quoted scalar when the
+// plain form would be ambiguous
+func yamlString(s string) string {
+	if yamlPlainRegex.MatchString(s) && !strings.HasSuffix(s, " ") {
+		switch strings.ToLower(s) {
+		case "true", "false", "yes", "no", "on", "off", "y", "n", "null":
+		default:
+			return s
+		}
+	}
+	// JSON strings are valid double-quoted YAML scalars
+	data, _ := json.Marshal(s)
+	return string(data)
+}
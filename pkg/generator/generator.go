@@ -2,13 +2,16 @@
 package generator
 
 import (
+	"context"
 	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 	"unicode"
@@ -16,45 +19,87 @@ import (
 
 // TypeScriptType represents a TypeScript type definition
 type TypeScriptType struct {
-	Name        string
-	Fields      []TypeScriptField
-	IsInterface bool
-	Comment     string
-	IsExported  bool           // Whether the type is exported
-	IsAPIType   bool           // Whether the type is API-related
-	Endpoints   []EndpointInfo // Information about API endpoints using this type
+	Name        string            `json:"name"`
+	Fields      []TypeScriptField `json:"fields"`
+	IsInterface bool              `json:"isInterface"`
+	Comment     string            `json:"comment,omitempty"`
+	IsExported  bool              `json:"isExported"`            // Whether the type is exported
+	Package     string            `json:"package,omitempty"`     // Name of the Go package declaring the type
+	PackagePath string            `json:"packagePath,omitempty"` // Import path of the Go package declaring the type
+	Position    *Position         `json:"position,omitempty"`    // Declaration of the type, nil for generated types
+	IsAPIType   bool              `json:"isAPIType"`             // Whether the type is API-related
+	APIReasons  []string          `json:"apiReasons,omitempty"`  // Signals that caused the type to be classified as API-related
+	Endpoints   []EndpointInfo    `json:"endpoints"`             // Information about API endpoints using this type
 }
 
 // EndpointInfo represents information about an API endpoint
 type EndpointInfo struct {
-	Method   string // HTTP method (GET, POST, etc.)
-	Path     string // API path
-	Response bool   // Whether the type is used as a response
-	Request  bool   // Whether the type is used as a request
+	Method      string   `json:"method"`                // HTTP method (GET, POST, etc.)
+	Path        string   `json:"path"`                  // API path
+	Response    bool     `json:"response,omitempty"`    // Whether the type is used as a response
+	Request     bool     `json:"request,omitempty"`     // Whether the type is used as a request
+	Status      string   `json:"status,omitempty"`      // Status code of the response, empty for requests
+	Summary     string   `json:"summary,omitempty"`     // Summary of the operation
+	OperationID string   `json:"operationId,omitempty"` // Operation ID declared with @ID
+	Tags        []string `json:"tags,omitempty"`        // Tags of the operation
+	Deprecated  bool     `json:"deprecated,omitempty"`  // Whether the operation is deprecated
 }
 
 // TypeScriptField represents a field in a TypeScript type
 type TypeScriptField struct {
-	Name       string
-	Type       string
-	Optional   bool
-	Comment    string
-	IsExported bool // Whether the field is exported
-	Validation []string
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+	Optional   bool      `json:"optional,omitempty"`
+	Comment    string    `json:"comment,omitempty"`
+	IsExported bool      `json:"isExported"` // Whether the field is exported
+	Validation []string  `json:"validation,omitempty"`
+	TypeRef    *TypeRef  `json:"typeRef,omitempty"`  // Structured form of Type, nil for fields not collected from Go code
+	GoType     string    `json:"goType,omitempty"`   // Go type expression of the field (e.g. []*models.User)
+	Tag        string    `json:"tag,omitempty"`      // Raw struct tag of the Go field
+	Readonly   bool      `json:"readonly,omitempty"` // Whether the property is rendered readonly
+	Position   *Position `json:"position,omitempty"` // Declaration of the field, nil for generated fields
+}
+
+// Position represents the position of a declaration in the Go sources
+type Position struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
 }
 
 // GenerateTypesFromMultipleDirs parses Go files from multiple source directories and generates TypeScript type definitions
-// in the target file.
-func GenerateTypesFromMultipleDirs(sourceDirs []string, targetFile string) error {
+// in the target file. The default options are changed by the functional options, e.g. WithFieldTransform.
+func GenerateTypesFromMultipleDirs(sourceDirs []string, targetFile string, options ...Option) error {
+	return GenerateTypesWithOptions(sourceDirs, targetFile, applyOptions(options))
+}
+
+// GenerateTypesWithOptions parses Go files from multiple source directories and generates TypeScript type definitions
+// in the target file using the given options.
+func GenerateTypesWithOptions(sourceDirs []string, targetFile string, opts Options) error {
+	g := New(WithOptions(opts))
+	if err := g.Load(context.Background(), sourceDirs...); err != nil {
+		return err
+	}
+
+	// Generate TypeScript type definitions from all collected types
+	return g.WriteFile(targetFile)
+}
+
+// collectModel collects and classifies the type definitions and operations from all source directories
+func collectModel(ctx context.Context, sourceDirs []string, opts Options) (*Model, error) {
+	ctx = withPackage(ctx, opts.Package)
+
 	// Map to store type names and their corresponding TypeScriptType objects
 	typeMap := make(map[string]*TypeScriptType)
+	// Type names in the order they were first collected
+	var typeOrder []string
 
 	// First pass: collect all type definitions from all directories
 	for _, sourceDir := range sourceDirs {
 		// Collect type definitions from the current source directory
-		types, err := CollectTypeDefinitions(sourceDir)
+		types, err := collectTypeDefinitions(ctx, sourceDir, opts)
 		if err != nil {
-			return fmt.Errorf("error collecting type definitions from directory %s: %w", sourceDir, err)
+			return nil, fmt.Errorf("error collecting type definitions from directory %s: %w", sourceDir, err)
 		}
 
 		// Add types to the map
@@ -62,31 +107,99 @@ func GenerateTypesFromMultipleDirs(sourceDirs []string, targetFile string) error
 			if _, exists := typeMap[t.Name]; !exists {
 				typeCopy := t // Create a copy to avoid modifying the original
 				typeMap[t.Name] = &typeCopy
+				typeOrder = append(typeOrder, t.Name)
 			}
 		}
 	}
 
-	// Second pass: collect endpoint information from all directories
+	// Standard library structs referenced by the collected types are declared with them
+	for _, name := range typeOrder {
+		for _, field := range typeMap[name].Fields {
+			for _, ref := range field.TypeRef.NamedTypes() {
+				if _, exists := typeMap[ref]; exists {
+					continue
+				}
+				if st, ok := stdlibStructType(ref); ok {
+					typeMap[ref] = &st
+					typeOrder = append(typeOrder, ref)
+				}
+			}
+		}
+	}
+
+	// Interfaces become unions of their implementations
+	if err := applyInterfaceUnions(ctx, sourceDirs, typeMap, typeOrder, opts.InterfaceUnions); err != nil {
+		return nil, err
+	}
+
+	// Second pass: collect operations and endpoint information from all directories
+	model := &Model{}
 	for _, sourceDir := range sourceDirs {
-		// Collect endpoint information from the current source directory
-		err := CollectEndpointInfo(sourceDir, typeMap)
+		// Collect operations from the current source directory
+		operations, info, err := collectOperations(ctx, sourceDir)
 		if err != nil {
-			return fmt.Errorf("error collecting endpoint information from directory %s: %w", sourceDir, err)
+			return nil, fmt.Errorf("error collecting endpoint information from directory %s: %w", sourceDir, err)
 		}
+		model.Operations = append(model.Operations, operations...)
+		model.Info.merge(info)
+	}
+	// The swagger signals only come from the annotated operations, before the discovered ones
+	annotated := len(model.Operations)
+	if opts.DiscoverRoutes {
+		discovered, err := discoverRoutes(ctx, sourceDirs)
+		if err != nil {
+			return nil, err
+		}
+		model.Operations = mergeOperations(model.Operations, discovered)
 	}
 
-	// Convert the map to a slice
-	var allTypes []TypeScriptType
-	for _, t := range typeMap {
-		allTypes = append(allTypes, *t)
+	// Composite types of the annotations become type aliases used as the endpoint types
+	composites := compositeTypes(model.Operations, typeMap)
+	for i := range composites {
+		typeMap[composites[i].Name] = &composites[i]
+		typeOrder = append(typeOrder, composites[i].Name)
 	}
+	linkEndpoints(model.Operations, typeMap)
 
-	// Generate TypeScript type definitions from all collected types
-	if err := GenerateTypeScriptTypes(allTypes, targetFile); err != nil {
-		return err
+	// Third pass: classify API types from swagger, handler and annotation signals
+	if err := classifyAPITypes(ctx, sourceDirs, typeMap, model.Operations[:annotated], opts.APIRules); err != nil {
+		return nil, err
+	}
+	if opts.APIRules.Swagger {
+		for _, composite := range composites {
+			t := typeMap[composite.Name]
+			for _, endpoint := range t.Endpoints {
+				usage := "response"
+				if endpoint.Request {
+					usage = "request"
+				}
+				addAPIReason(t, fmt.Sprintf("swagger: composite %s on %s %s", usage, endpoint.Method, endpoint.Path))
+			}
+		}
 	}
 
-	return nil
+	// Convert the map to a slice, keeping the collection order
+	allTypes := make([]TypeScriptType, 0, len(typeOrder))
+	for _, name := range typeOrder {
+		allTypes = append(allTypes, *typeMap[name])
+	}
+
+	allTypes = applyInputVariants(allTypes, model.Operations)
+
+	if opts.TimeAsDate {
+		applyDateType(allTypes)
+	}
+	applyReadonly(allTypes, opts.Readonly)
+
+	model.Types = allTypes
+	compositeNames := make(map[string]bool)
+	for _, composite := range composites {
+		compositeNames[composite.Name] = true
+	}
+	if err := renameTypes(model, compositeNames, opts.TypeNaming); err != nil {
+		return nil, err
+	}
+	return model, nil
 }
 
 // GenerateTypes parses Go files in the source directory and generates TypeScript type definitions
@@ -118,8 +231,8 @@ func ParseGoFiles(sourceDir string) ([]TypeScriptType, error) {
 	}
 
 	// Add types to the map
-	for _, t := range types {
-		typeMap[t.Name] = &t
+	for i := range types {
+		typeMap[types[i].Name] = &types[i]
 	}
 
 	// Second pass: collect endpoint information
@@ -128,26 +241,26 @@ func ParseGoFiles(sourceDir string) ([]TypeScriptType, error) {
 		return nil, err
 	}
 
-	// Convert the map values to a slice for the final result
-	result := make([]TypeScriptType, 0, len(types))
-	for _, t := range types {
-		// Add any additional endpoints from the map
-		if mapType, exists := typeMap[t.Name]; exists && len(mapType.Endpoints) > 0 {
-			// Merge endpoints from the map with the ones already in the type
-			t.Endpoints = append(t.Endpoints, mapType.Endpoints...)
-		}
-		result = append(result, t)
+	// Third pass: classify API types
+	if err := ClassifyAPITypes([]string{sourceDir}, typeMap, DefaultClassificationRules()); err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	return types, nil
 }
 
 // CollectTypeDefinitions collects type definitions from Go files in the source directory
 func CollectTypeDefinitions(sourceDir string) ([]TypeScriptType, error) {
+	return collectTypeDefinitions(context.Background(), sourceDir, DefaultOptions())
+}
+
+// collectTypeDefinitions collects type definitions from Go files in the source directory using the given options
+func collectTypeDefinitions(ctx context.Context, sourceDir string, opts Options) ([]TypeScriptType, error) {
 	var types []TypeScriptType
+	packagePaths := make(packagePathCache)
 
 	// Walk through the source directory
-	err := filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+	err := walkContext(ctx, sourceDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -162,14 +275,19 @@ func CollectTypeDefinitions(sourceDir string) ([]TypeScriptType, error) {
 				return nil
 			}
 
-			// Determine if the file is API-related based on the file path
-			isAPIFile := strings.Contains(path, "controller") || strings.Contains(path, "handler") || strings.Contains(path, "api")
+			// Convert field types using the file's imports to resolve well-known types
+			converter := newTypeConverter(node, opts.TypeMappings)
+			pkgPath := packagePaths.lookup(filepath.Dir(path))
 
 			// Collect type definitions
 			for _, decl := range node.Decls {
 				if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.TYPE {
 					for _, spec := range genDecl.Specs {
 						if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+							if !keepNode(opts.Filters, typeSpec, nil) {
+								continue
+							}
+
 							// Check if the type is exported
 							isExported := unicode.IsUpper(rune(typeSpec.Name.Name[0]))
 
@@ -179,15 +297,10 @@ func CollectTypeDefinitions(sourceDir string) ([]TypeScriptType, error) {
 									Name:        typeSpec.Name.Name,
 									IsInterface: true,
 									IsExported:  isExported,
-									IsAPIType: isAPIFile ||
-										strings.Contains(typeSpec.Name.Name, "Request") ||
-										strings.Contains(typeSpec.Name.Name, "Response") ||
-										strings.Contains(typeSpec.Name.Name, "Params") ||
-										strings.Contains(typeSpec.Name.Name, "Param") ||
-										strings.Contains(typeSpec.Name.Name, "Form") ||
-										strings.Contains(path, "form") ||
-										strings.Contains(path, "api"),
-									Endpoints: []EndpointInfo{},
+									Package:     node.Name.Name,
+									PackagePath: pkgPath,
+									Position:    sourcePosition(fset, typeSpec.Pos()),
+									Endpoints:   []EndpointInfo{},
 								}
 
 								// Get comments
@@ -198,9 +311,10 @@ func CollectTypeDefinitions(sourceDir string) ([]TypeScriptType, error) {
 								// Collect fields
 								if structType.Fields != nil {
 									for _, field := range structType.Fields.List {
-										if len(field.Names) > 0 {
-											fieldName := field.Names[0].Name
-											fieldType, isPointer := getTypeString(field.Type)
+										// Emit every name of a multi-name field (e.g. Lat, Lng float64) as its own property
+										for _, name := range field.Names {
+											fieldName := name.Name
+											fieldTypeRef := converter.convert(field.Type)
 
 											// Check if the field is exported
 											isFieldExported := unicode.IsUpper(rune(fieldName[0]))
@@ -213,13 +327,15 @@ func CollectTypeDefinitions(sourceDir string) ([]TypeScriptType, error) {
 
 											// Parse tags
 											jsonName := fieldName
+											tagged := false              // Whether the name comes from a tag
 											optional := false            // Default to not optional
-											_ = isPointer                // We use isPointer elsewhere but not for optionality
 											var validationRules []string // Store validation rules for JSDoc
 											isRequired := false          // Track if the field is explicitly required
+											rawTag := ""
 
 											if field.Tag != nil {
-												tag := strings.Trim(field.Tag.Value, "`")
+												tag := structTag(field.Tag.Value)
+												rawTag = tag
 
 												// Extract validation rules
 												bindingTag := extractTag(tag, "binding")
@@ -249,11 +365,18 @@ func CollectTypeDefinitions(sourceDir string) ([]TypeScriptType, error) {
 												paramTag := extractTag(tag, "param")
 												queryTag := extractTag(tag, "query")
 
+												// encoding/json skips fields tagged json:"-", while json:"-," names
+												// the property "-"
+												if jsonTag == "-" {
+													continue
+												}
+
 												// First priority: json tag
 												if jsonTag != "" {
 													parts := strings.Split(jsonTag, ",")
-													if parts[0] != "" && parts[0] != "-" {
+													if parts[0] != "" {
 														jsonName = parts[0]
+														tagged = true
 													}
 													for _, part := range parts[1:] {
 														if part == "omitempty" {
@@ -265,6 +388,7 @@ func CollectTypeDefinitions(sourceDir string) ([]TypeScriptType, error) {
 													parts := strings.Split(formTag, ",")
 													if parts[0] != "" && parts[0] != "-" {
 														jsonName = parts[0]
+														tagged = true
 													}
 													for _, part := range parts[1:] {
 														if part == "omitempty" {
@@ -276,6 +400,7 @@ func CollectTypeDefinitions(sourceDir string) ([]TypeScriptType, error) {
 													parts := strings.Split(paramTag, ",")
 													if parts[0] != "" && parts[0] != "-" {
 														jsonName = parts[0]
+														tagged = true
 													}
 													for _, part := range parts[1:] {
 														if part == "omitempty" {
@@ -287,6 +412,7 @@ func CollectTypeDefinitions(sourceDir string) ([]TypeScriptType, error) {
 													parts := strings.Split(queryTag, ",")
 													if parts[0] != "" && parts[0] != "-" {
 														jsonName = parts[0]
+														tagged = true
 													}
 													for _, part := range parts[1:] {
 														if part == "omitempty" {
@@ -301,33 +427,45 @@ func CollectTypeDefinitions(sourceDir string) ([]TypeScriptType, error) {
 												optional = false
 											}
 
-											// Always use the tag name if available, without converting to camelCase
-											finalFieldName := jsonName
+											// Tag names are used as-is unless the naming strategy applies to all fields
+											finalFieldName := propertyName(jsonName, tagged, opts)
 
-											tsType.Fields = append(tsType.Fields, TypeScriptField{
+											tsField := TypeScriptField{
 												Name:       finalFieldName,
-												Type:       fieldType,
+												Type:       fieldTypeRef.String(),
+												TypeRef:    fieldTypeRef,
 												Optional:   optional,
 												Comment:    fieldComment,
 												IsExported: isFieldExported,
 												Validation: validationRules, // Add validation rules
-											})
+												GoType:     goTypeExpr(field.Type),
+												Tag:        rawTag,
+												Position:   sourcePosition(fset, name.Pos()),
+											}
+											if !keepNode(opts.Filters, field, &tsField) {
+												continue
+											}
+											transformField(opts.FieldTransforms, field, &tsField)
+											tsType.Fields = append(tsType.Fields, tsField)
 										}
 									}
 								}
 
 								// Add type to the list
+								transformType(opts.TypeTransforms, typeSpec, &tsType)
 								types = append(types, tsType)
 							} else {
 								// For non-struct types (type aliases, etc.)
 								tsTypeName := typeSpec.Name.Name
-								tsTypeValue, _ := getTypeString(typeSpec.Type)
+								tsTypeValue := converter.convert(typeSpec.Type)
 
 								tsType := TypeScriptType{
 									Name:        tsTypeName,
 									IsInterface: false,
 									IsExported:  isExported,
-									IsAPIType:   isAPIFile || strings.Contains(tsTypeName, "Request") || strings.Contains(tsTypeName, "Response") || strings.Contains(tsTypeName, "Params"),
+									Package:     node.Name.Name,
+									PackagePath: pkgPath,
+									Position:    sourcePosition(fset, typeSpec.Pos()),
 								}
 
 								// Get comments
@@ -338,13 +476,17 @@ func CollectTypeDefinitions(sourceDir string) ([]TypeScriptType, error) {
 								// Add a single field to represent the type alias
 								tsType.Fields = append(tsType.Fields, TypeScriptField{
 									Name:       "value",
-									Type:       tsTypeValue,
+									Type:       tsTypeValue.String(),
+									TypeRef:    tsTypeValue,
 									Optional:   false,
 									Comment:    "",
 									IsExported: true,
+									GoType:     goTypeExpr(typeSpec.Type),
+									Position:   sourcePosition(fset, typeSpec.Type.Pos()),
 								})
 
 								// Add type to the list
+								transformType(opts.TypeTransforms, typeSpec, &tsType)
 								types = append(types, tsType)
 							}
 						}
@@ -356,242 +498,136 @@ func CollectTypeDefinitions(sourceDir string) ([]TypeScriptType, error) {
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("error walking directory: %v", err)
+		return nil, fmt.Errorf("error walking directory: %w", err)
 	}
 
 	return types, nil
 }
 
+// Regular expressions for parsing Swagger/OpenAPI annotations
+var (
+	routerRegex = regexp.MustCompile(`@Router\s+([^\s\[]+)\s+\[([^\]]+)\]`)
+)
+
 // CollectEndpointInfo collects endpoint information from Go files in the source directory
 func CollectEndpointInfo(sourceDir string, typeMap map[string]*TypeScriptType) error {
-	// Regular expressions for parsing Swagger/OpenAPI annotations
-	routerRegex := regexp.MustCompile(`@Router\s+([^\s\[]+)\s+\[([^\]]+)\]`)
-	// Handle different formats of @Success annotation
-	successRegex := regexp.MustCompile(`@Success\s+\d+\s+\{([^}]+)\}\s+(\S+)`)
-	successRegexAlt := regexp.MustCompile(`@Success\s+\d+\s+(\S+)`) // Alternative format without braces
-	paramRegex := regexp.MustCompile(`@Param\s+\S+\s+(body|path|query)\s+(\S+)`)
+	operations, _, err := CollectOperations(sourceDir)
+	if err != nil {
+		return fmt.Errorf("error walking directory for endpoint information: %v", err)
+	}
+	linkEndpoints(operations, typeMap)
+	return nil
+}
 
-	// Walk through the source directory
-	err := filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
+// linkEndpoints records the operations on the types they use as request body or response
+func linkEndpoints(operations []Operation, typeMap map[string]*TypeScriptType) {
+	for _, op := range operations {
+		endpoint := EndpointInfo{
+			Method:      op.Method,
+			Path:        op.Path,
+			Summary:     op.Summary,
+			OperationID: op.ID,
+			Tags:        op.Tags,
+			Deprecated:  op.Deprecated,
 		}
 
-		// Process only Go files
-		if !info.IsDir() && strings.HasSuffix(path, ".go") {
-			// Parse the file
-			fset := token.NewFileSet()
-			node, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
-			if err != nil {
-				fmt.Printf("Error parsing file %s: %v\n", path, err)
-				return nil
+		for _, resp := range op.Responses {
+			for _, name := range resp.Type.NamedTypes() {
+				if typeObj, exists := typeMap[name]; exists {
+					responseEndpoint := endpoint
+					responseEndpoint.Response = true
+					responseEndpoint.Status = resp.Status
+					typeObj.Endpoints = append(typeObj.Endpoints, responseEndpoint)
+				}
 			}
+		}
 
-			// Process function declarations to find their associated comments
-			for _, decl := range node.Decls {
-				if funcDecl, ok := decl.(*ast.FuncDecl); ok {
-					// Skip methods without doc comments
-					if funcDecl.Doc == nil {
-						continue
-					}
-
-					comment := funcDecl.Doc.Text()
-
-					// Extract endpoint information from Swagger/OpenAPI annotations
-					routerMatches := routerRegex.FindStringSubmatch(comment)
-					if len(routerMatches) >= 3 {
-						routePath := routerMatches[1]
-						method := routerMatches[2]
-
-						// Normalize path parameters (convert :param to {param})
-						normalizedPath := normalizePath(routePath)
-
-						// Extract response type information - try standard format first
-						successMatches := successRegex.FindAllStringSubmatch(comment, -1)
-						for _, match := range successMatches {
-							if len(match) >= 3 {
-								responseType := match[2]
-								// Remove array prefix if present
-								if strings.HasPrefix(responseType, "[]") {
-									responseType = strings.TrimPrefix(responseType, "[]")
-								}
-
-								// Extract the type name without package prefix if present
-								typeParts := strings.Split(responseType, ".")
-								typeNameOnly := typeParts[len(typeParts)-1]
-
-								// Store endpoint information for this type
-								if typeObj, exists := typeMap[typeNameOnly]; exists {
-									typeObj.Endpoints = append(typeObj.Endpoints, EndpointInfo{
-										Method:   method,
-										Path:     normalizedPath,
-										Response: true,
-										Request:  false,
-									})
-								}
-							}
-						}
-
-						// Try alternative format if no matches found
-						if len(successMatches) == 0 {
-							altMatches := successRegexAlt.FindAllStringSubmatch(comment, -1)
-							for _, match := range altMatches {
-								if len(match) >= 2 {
-									responseType := match[1]
-									// Remove array prefix if present
-									if strings.HasPrefix(responseType, "[]") {
-										responseType = strings.TrimPrefix(responseType, "[]")
-									}
-
-									// Extract the type name without package prefix if present
-									typeParts := strings.Split(responseType, ".")
-									typeNameOnly := typeParts[len(typeParts)-1]
-
-									// Store endpoint information for this type
-									if typeObj, exists := typeMap[typeNameOnly]; exists {
-										typeObj.Endpoints = append(typeObj.Endpoints, EndpointInfo{
-											Method:   method,
-											Path:     normalizedPath,
-											Response: true,
-											Request:  false,
-										})
-									}
-								}
-							}
-						}
-
-						// Extract request body type information
-						paramMatches := paramRegex.FindAllStringSubmatch(comment, -1)
-						for _, match := range paramMatches {
-							if len(match) >= 3 {
-								paramType := match[1]
-								requestType := match[2]
-
-								// Extract the type name without package prefix if present
-								typeParts := strings.Split(requestType, ".")
-								typeNameOnly := typeParts[len(typeParts)-1]
-
-								// Only consider body parameters as request types
-								if paramType == "body" {
-									// Store endpoint information for this type
-									if typeObj, exists := typeMap[typeNameOnly]; exists {
-										typeObj.Endpoints = append(typeObj.Endpoints, EndpointInfo{
-											Method:   method,
-											Path:     normalizedPath,
-											Response: false,
-											Request:  true,
-										})
-									}
-								}
-							}
-						}
-					}
+		// Only body parameters are request types
+		for _, param := range op.Parameters {
+			if param.In != "body" {
+				continue
+			}
+			for _, name := range param.Type.NamedTypes() {
+				if typeObj, exists := typeMap[name]; exists {
+					requestEndpoint := endpoint
+					requestEndpoint.Request = true
+					typeObj.Endpoints = append(typeObj.Endpoints, requestEndpoint)
 				}
 			}
+		}
+	}
+}
 
-			// Also process all comments in the file to catch any that might not be directly attached to functions
-			for _, commentGroup := range node.Comments {
-				comment := commentGroup.Text()
-
-				// Extract endpoint information from Swagger/OpenAPI annotations
-				routerMatches := routerRegex.FindStringSubmatch(comment)
-				if len(routerMatches) >= 3 {
-					routePath := routerMatches[1]
-					method := routerMatches[2]
-
-					// Normalize path parameters (convert :param to {param})
-					normalizedPath := normalizePath(routePath)
-
-					// Extract response type information - try standard format first
-					successMatches := successRegex.FindAllStringSubmatch(comment, -1)
-					for _, match := range successMatches {
-						if len(match) >= 3 {
-							responseType := match[2]
-							// Remove array prefix if present
-							if strings.HasPrefix(responseType, "[]") {
-								responseType = strings.TrimPrefix(responseType, "[]")
-							}
-
-							// Extract the type name without package prefix if present
-							typeParts := strings.Split(responseType, ".")
-							typeNameOnly := typeParts[len(typeParts)-1]
-
-							// Store endpoint information for this type
-							if typeObj, exists := typeMap[typeNameOnly]; exists {
-								typeObj.Endpoints = append(typeObj.Endpoints, EndpointInfo{
-									Method:   method,
-									Path:     normalizedPath,
-									Response: true,
-									Request:  false,
-								})
-							}
-						}
-					}
+// endpointUsage collects how a type is used by an endpoint
+type endpointUsage struct {
+	endpoint EndpointInfo
+	request  bool
+	statuses []string
+}
 
-					// Try alternative format if no matches found
-					if len(successMatches) == 0 {
-						altMatches := successRegexAlt.FindAllStringSubmatch(comment, -1)
-						for _, match := range altMatches {
-							if len(match) >= 2 {
-								responseType := match[1]
-								// Remove array prefix if present
-								if strings.HasPrefix(responseType, "[]") {
-									responseType = strings.TrimPrefix(responseType, "[]")
-								}
+// writeEndpointDocs writes the JSDoc lines listing the endpoints using a type, grouped by
+// method and path in the order they were declared. Operation IDs are listed with @see, and
+// the type is marked @deprecated when every endpoint using it is deprecated.
+func writeEndpointDocs(w io.Writer, endpoints []EndpointInfo) {
+	var usages []*endpointUsage
+	usageMap := make(map[string]*endpointUsage)
+	for _, endpoint := range endpoints {
+		key := fmt.Sprintf("%s %s", endpoint.Method, endpoint.Path)
+		usage, exists := usageMap[key]
+		if !exists {
+			usage = &endpointUsage{endpoint: endpoint}
+			usageMap[key] = usage
+			usages = append(usages, usage)
+		}
+		if endpoint.Request {
+			usage.request = true
+		}
+		if endpoint.Response && !containsString(usage.statuses, endpoint.Status) {
+			usage.statuses = append(usage.statuses, endpoint.Status)
+		}
+	}
 
-								// Extract the type name without package prefix if present
-								typeParts := strings.Split(responseType, ".")
-								typeNameOnly := typeParts[len(typeParts)-1]
-
-								// Store endpoint information for this type
-								if typeObj, exists := typeMap[typeNameOnly]; exists {
-									typeObj.Endpoints = append(typeObj.Endpoints, EndpointInfo{
-										Method:   method,
-										Path:     normalizedPath,
-										Response: true,
-										Request:  false,
-									})
-								}
-							}
-						}
-					}
+	fmt.Fprintln(w, " * @api Used in the following endpoints:")
+	allDeprecated := true
+	for _, usage := range usages {
+		var kinds []string
+		if len(usage.statuses) > 0 {
+			kinds = append(kinds, strings.TrimSpace("Response "+strings.Join(usage.statuses, ", ")))
+		}
+		if usage.request {
+			kinds = append(kinds, "Request")
+		}
 
-					// Extract request body type information
-					paramMatches := paramRegex.FindAllStringSubmatch(comment, -1)
-					for _, match := range paramMatches {
-						if len(match) >= 3 {
-							paramType := match[1]
-							requestType := match[2]
-
-							// Extract the type name without package prefix if present
-							typeParts := strings.Split(requestType, ".")
-							typeNameOnly := typeParts[len(typeParts)-1]
-
-							// Only consider body parameters as request types
-							if paramType == "body" {
-								// Store endpoint information for this type
-								if typeObj, exists := typeMap[typeNameOnly]; exists {
-									typeObj.Endpoints = append(typeObj.Endpoints, EndpointInfo{
-										Method:   method,
-										Path:     normalizedPath,
-										Response: false,
-										Request:  true,
-									})
-								}
-							}
-						}
-					}
-				}
-			}
+		line := fmt.Sprintf(" * - %s %s (%s)", usage.endpoint.Method, usage.endpoint.Path, strings.Join(kinds, ", "))
+		if usage.endpoint.Summary != "" {
+			line += ": " + usage.endpoint.Summary
 		}
-		return nil
-	})
+		if usage.endpoint.Deprecated {
+			line += " (deprecated)"
+		} else {
+			allDeprecated = false
+		}
+		fmt.Fprintln(w, line)
+	}
 
-	if err != nil {
-		return fmt.Errorf("error walking directory for endpoint information: %v", err)
+	for _, usage := range usages {
+		if usage.endpoint.OperationID != "" {
+			fmt.Fprintf(w, " * @see %s (%s %s)\n", usage.endpoint.OperationID, usage.endpoint.Method, usage.endpoint.Path)
+		}
 	}
+	if allDeprecated {
+		fmt.Fprintln(w, " * @deprecated Only used by deprecated endpoints")
+	}
+}
 
-	return nil
+// containsString checks if a slice contains a string
+func containsString(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
+			return true
+		}
+	}
+	return false
 }
 
 // normalizePath converts different path parameter formats to a consistent format
@@ -602,80 +638,129 @@ func normalizePath(path string) string {
 	return colonParamRegex.ReplaceAllString(path, "{$1}")
 }
 
-// getTypeString gets a TypeScript type string from a Go type expression
-// Returns (type string, whether it's a pointer type)
-func getTypeString(expr ast.Expr) (string, bool) {
-	switch t := expr.(type) {
-	case *ast.Ident:
-		switch t.Name {
-		case "string":
-			return "string", false
-		case "bool":
-			return "boolean", false
-		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
-			return "number", false
-		case "time.Time":
-			return "string /* RFC3339 */", false
-		default:
-			// Return type name as is without converting to PascalCase
-			return t.Name, false
-		}
-	case *ast.ArrayType:
-		// Check if the element type is a pointer
-		if starExpr, isPointer := t.Elt.(*ast.StarExpr); isPointer {
-			// For array of pointers, get the base type
-			baseType, _ := getTypeString(starExpr.X)
-			// Return as an array type with nullable elements
-			return "(" + baseType + " | null)[]", true
-		}
-		// Regular array type
-		elemType, _ := getTypeString(t.Elt)
-		return elemType + "[]", false
-	case *ast.MapType:
-		keyType, _ := getTypeString(t.Key)
-		valueType, _ := getTypeString(t.Value)
-		return "Record<" + keyType + ", " + valueType + ">", false
-	case *ast.SelectorExpr:
-		if ident, ok := t.X.(*ast.Ident); ok && ident.Name == "time" && t.Sel.Name == "Time" {
-			return "string /* RFC3339 */", false
-		}
-		return t.Sel.Name, false
-	case *ast.StarExpr:
-		// For pointer types, get the base type and return a flag indicating it's a pointer
-		baseType, _ := getTypeString(t.X)
-		return baseType + " | null", true
-	case *ast.InterfaceType:
-		return "any", false
-	default:
-		return "any", false
+// GenerateTypeScriptTypes generates TypeScript type definitions
+func GenerateTypeScriptTypes(types []TypeScriptType, targetFile string) error {
+	return generateTypeScript(types, targetFile, DefaultOptions())
+}
+
+// generateTypeScript writes the types to the target file, moving the API types to a
+// separate file when opts.APITypesFile is set
+func generateTypeScript(types []TypeScriptType, targetFile string, opts Options) error {
+	if opts.APITypesFile == "" {
+		return writeTypeScriptFile(targetFile, types, types, nil, opts)
 	}
+
+	var apiTypes, modelTypes []TypeScriptType
+	for _, t := range types {
+		if t.IsAPIType {
+			apiTypes = append(apiTypes, t)
+		} else {
+			modelTypes = append(modelTypes, t)
+		}
+	}
+
+	if err := writeTypeScriptFile(targetFile, modelTypes, types, typeImports(modelTypes, apiTypes, targetFile, opts.APITypesFile, opts), opts); err != nil {
+		return err
+	}
+	return writeTypeScriptFile(opts.APITypesFile, apiTypes, types, typeImports(apiTypes, modelTypes, opts.APITypesFile, targetFile, opts), opts)
+}
+
+// tsImport represents an import from another generated file
+type tsImport struct {
+	Names  []string // Imported type names
+	Values []string // Imported functions
+	From   string   // Module specifier relative to the importing file
 }
 
-// extractTag extracts a specific tag value from a tag string
-func extractTag(tag, key string) string {
-	for _, t := range strings.Split(tag, " ") {
-		if strings.HasPrefix(t, key+":") {
-			value := strings.TrimPrefix(t, key+":")
-			return strings.Trim(value, "\"")
+// typeImports returns the imports needed by types for the names defined in otherTypes,
+// which are written to otherFile
+func typeImports(types, otherTypes []TypeScriptType, file, otherFile string, opts Options) []tsImport {
+	defined := make(map[string]bool)
+	for _, t := range otherTypes {
+		defined[t.Name] = true
+	}
+
+	used := make(map[string]bool)
+	for _, t := range types {
+		for _, field := range t.Fields {
+			for _, name := range fieldTypeNames(field) {
+				if defined[name] {
+					used[name] = true
+				}
+			}
+		}
+	}
+	if len(used) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Date helpers call the helpers of the types they reference
+	var values []string
+	if opts.TimeAsDate {
+		dateTypes := dateTypeNames(otherTypes)
+		for _, name := range names {
+			if dateTypes[name] {
+				parseName, serializeName := dateHelperNames(name)
+				values = append(values, parseName, serializeName)
+			}
 		}
 	}
-	return ""
+
+	return []tsImport{{Names: names, Values: values, From: moduleSpecifier(file, otherFile)}}
 }
 
-// GenerateTypeScriptTypes generates TypeScript type definitions
-func GenerateTypeScriptTypes(types []TypeScriptType, targetFile string) error {
+// moduleSpecifier returns the relative module specifier used to import target from file
+// e.g. (types/api.ts, types/models.ts) -> ./models
+func moduleSpecifier(file, target string) string {
+	rel, err := filepath.Rel(filepath.Dir(file), target)
+	if err != nil {
+		rel = target
+	}
+	rel = filepath.ToSlash(strings.TrimSuffix(rel, filepath.Ext(rel)))
+	if !strings.HasPrefix(rel, ".") {
+		rel = "./" + rel
+	}
+	return rel
+}
+
+// writeTypeScriptFile creates the target file and writes the types to it.
+// allTypes contains every type defined across the generated files and is used to decide
+// which referenced types need placeholders.
+func writeTypeScriptFile(targetFile string, types, allTypes []TypeScriptType, imports []tsImport, opts Options) error {
 	file, err := os.Create(targetFile)
 	if err != nil {
 		return fmt.Errorf("error creating file: %v", err)
 	}
 	defer file.Close()
 
+	return writeTypeScript(file, types, allTypes, imports, opts)
+}
+
+// writeTypeScript writes the header, imports, placeholders and type definitions, with the
+// API types grouped before the remaining model types
+func writeTypeScript(file io.Writer, types, allTypes []TypeScriptType, imports []tsImport, opts Options) error {
 	// Collect undefined types
 	undefinedTypes := make(map[string]bool)
 	processedNullableTypes := make(map[string]bool)
 
 	for _, t := range types {
 		for _, field := range t.Fields {
+			// Use the structured type when available
+			if field.TypeRef != nil {
+				for _, name := range field.TypeRef.NamedTypes() {
+					if !typeExists(name, allTypes) {
+						undefinedTypes[name] = true
+					}
+				}
+				continue
+			}
+
 			// Extract base type from nullable types (remove " | null" suffix)
 			baseType := field.Type
 			if strings.HasSuffix(baseType, " | null") {
@@ -689,7 +774,7 @@ func GenerateTypeScriptTypes(types []TypeScriptType, targetFile string) error {
 			}
 
 			// Collect types that are not basic types and not defined
-			if !isBasicType(baseType) && !typeExists(baseType, types) && !strings.Contains(baseType, " | ") && !strings.Contains(baseType, "(") && !strings.Contains(baseType, ")") {
+			if !isBasicType(baseType) && !typeExists(baseType, allTypes) && !strings.Contains(baseType, " | ") && !strings.Contains(baseType, "(") && !strings.Contains(baseType, ")") {
 				undefinedTypes[baseType] = true
 			}
 		}
@@ -705,6 +790,17 @@ func GenerateTypeScriptTypes(types []TypeScriptType, targetFile string) error {
 `
 	fmt.Fprintf(file, header, time.Now().Format("2006-01-02 15:04:05"))
 
+	// Write imports from the other generated files
+	for _, imp := range imports {
+		fmt.Fprintf(file, "import type { %s } from \"%s\";\n", strings.Join(imp.Names, ", "), imp.From)
+		if len(imp.Values) > 0 {
+			fmt.Fprintf(file, "import { %s } from \"%s\";\n", strings.Join(imp.Values, ", "), imp.From)
+		}
+	}
+	if len(imports) > 0 {
+		fmt.Fprintln(file, "")
+	}
+
 	// Write placeholders for undefined types
 	if len(undefinedTypes) > 0 {
 		fmt.Fprintln(file, "// Placeholders for undefined types")
@@ -733,8 +829,28 @@ func GenerateTypeScriptTypes(types []TypeScriptType, targetFile string) error {
 		fmt.Fprintln(file, "")
 	}
 
-	// Write type definitions
+	// Group API types before model types
+	var apiTypes, modelTypes []TypeScriptType
 	for _, t := range types {
+		if t.IsAPIType {
+			apiTypes = append(apiTypes, t)
+		} else {
+			modelTypes = append(modelTypes, t)
+		}
+	}
+	grouped := len(apiTypes) > 0 && len(modelTypes) > 0
+
+	// Write type definitions
+	for i, t := range append(apiTypes, modelTypes...) {
+		// Write a section header at the start of each group
+		if grouped && i == 0 {
+			fmt.Fprintln(file, "// API types")
+			fmt.Fprintln(file, "")
+		} else if grouped && i == len(apiTypes) {
+			fmt.Fprintln(file, "// Model types")
+			fmt.Fprintln(file, "")
+		}
+
 		// Write comments
 		fmt.Fprintln(file, "/**")
 
@@ -742,7 +858,11 @@ func GenerateTypeScriptTypes(types []TypeScriptType, targetFile string) error {
 		if t.Comment != "" {
 			lines := strings.Split(strings.TrimSpace(t.Comment), "\n")
 			for _, line := range lines {
-				fmt.Fprintf(file, " * %s\n", strings.TrimSpace(line))
+				if line = strings.TrimSpace(line); line == "" {
+					fmt.Fprintln(file, " *")
+				} else {
+					fmt.Fprintf(file, " * %s\n", line)
+				}
 			}
 		}
 
@@ -753,39 +873,7 @@ func GenerateTypeScriptTypes(types []TypeScriptType, targetFile string) error {
 				fmt.Fprintln(file, " *")
 			}
 
-			// Group endpoints by path and method
-			endpointMap := make(map[string][]EndpointInfo)
-			for _, endpoint := range t.Endpoints {
-				key := fmt.Sprintf("%s %s", endpoint.Method, endpoint.Path)
-				endpointMap[key] = append(endpointMap[key], endpoint)
-			}
-
-			// Add endpoint information
-			fmt.Fprintln(file, " * @api Used in the following endpoints:")
-			for key, endpoints := range endpointMap {
-				usages := []string{}
-				for _, endpoint := range endpoints {
-					if endpoint.Response {
-						usages = append(usages, "Response")
-					}
-					if endpoint.Request {
-						usages = append(usages, "Request")
-					}
-				}
-
-				// Remove duplicates from usages
-				uniqueUsages := []string{}
-				usageMap := make(map[string]bool)
-				for _, usage := range usages {
-					if !usageMap[usage] {
-						usageMap[usage] = true
-						uniqueUsages = append(uniqueUsages, usage)
-					}
-				}
-
-				usageStr := strings.Join(uniqueUsages, ", ")
-				fmt.Fprintf(file, " * - %s (%s)\n", key, usageStr)
-			}
+			writeEndpointDocs(file, t.Endpoints)
 		}
 
 		fmt.Fprintln(file, " */")
@@ -848,7 +936,11 @@ func GenerateTypeScriptTypes(types []TypeScriptType, targetFile string) error {
 				if field.Optional {
 					optionalMark = "?"
 				}
-				fmt.Fprintf(file, "  %s%s: %s;\n", field.Name, optionalMark, field.Type)
+				readonlyMark := ""
+				if field.Readonly {
+					readonlyMark = "readonly "
+				}
+				fmt.Fprintf(file, "  %s%s%s: %s;\n", readonlyMark, tsPropertyKey(field.Name), optionalMark, field.Type)
 			}
 			fmt.Fprintln(file, "}")
 		} else {
@@ -861,6 +953,11 @@ func GenerateTypeScriptTypes(types []TypeScriptType, targetFile string) error {
 		fmt.Fprintln(file)
 	}
 
+	// Write helpers converting date fields between their JSON and Date representations
+	if opts.TimeAsDate {
+		writeDateHelpers(file, types, allTypes, dateTypeNames(allTypes))
+	}
+
 	return nil
 }
 
@@ -906,29 +1003,6 @@ func typeExists(typeName string, types []TypeScriptType) bool {
 	return false
 }
 
-// toCamelCase converts a snake_case or PascalCase string to camelCase
-func toCamelCase(s string) string {
-	// If already camelCase, return as is
-	if !strings.Contains(s, "_") && !unicode.IsUpper(rune(s[0])) {
-		return s
-	}
-
-	// For snake_case
-	if strings.Contains(s, "_") {
-		words := strings.Split(s, "_")
-		result := strings.ToLower(words[0])
-		for i := 1; i < len(words); i++ {
-			if words[i] != "" {
-				result += strings.ToUpper(words[i][:1]) + strings.ToLower(words[i][1:])
-			}
-		}
-		return result
-	}
-
-	// For PascalCase
-	return strings.ToLower(s[:1]) + s[1:]
-}
-
 // isReservedTypeName checks if a type name is a reserved TypeScript keyword
 func isReservedTypeName(name string) bool {
 	reservedNames := map[string]bool{
@@ -0,0 +1,223 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestSwagAnnotations tests the parsing of the full swag annotation set into operations,
+// endpoint information, JSDoc and the OpenAPI document
+func TestSwagAnnotations(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-annotations-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file using every standard swag annotation
+	goFilePath := filepath.Join(tempDir, "api.go")
+	goFileContent := `package api
+
+// @title Orders API
+// @version 1.0
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization
+// @description Bearer token
+// @securityDefinitions.oauth2.accessCode OAuth2
+// @tokenUrl https://example.com/oauth/token
+// @authorizationUrl https://example.com/oauth/authorize
+// @scope.read Grants read access
+// @scope.write Grants write access
+
+// Order represents an order
+type Order struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// LegacyOrder is only returned by the legacy endpoint
+type LegacyOrder struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// ErrorResponse is returned on errors
+type ErrorResponse struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+
+// GetOrder godoc
+// @ID getOrder
+// @Summary Get an order
+// @Tags orders
+// @Accept json
+// @Produce json,xml
+// @Param id path string true "Order ID"
+// @Success 200 {object} Order
+// @Failure 404,500 {object} ErrorResponse "Error"
+// @Header 200 {string} ETag "Entity tag"
+// @Header all {string} X-Request-ID "Request ID"
+// @Security ApiKeyAuth || OAuth2[read, write]
+// @Router /orders/{id} [get]
+func GetOrder() {}
+
+// GetLegacyOrder godoc
+// @ID getLegacyOrder
+// @Summary Get an order (legacy)
+// @Deprecated
+// @Success 200 {object} LegacyOrder
+// @Failure 404 {object} ErrorResponse
+// @Security ApiKeyAuth && OAuth2[read]
+// @Router /legacy/orders/{id} [get]
+func GetLegacyOrder() {}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	model, err := CollectModel([]string{tempDir}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+	if len(model.Operations) != 2 {
+		t.Fatalf("Expected 2 operations, got %d", len(model.Operations))
+	}
+
+	// Operation model
+	op := model.Operations[0]
+	if op.ID != "getOrder" || op.Summary != "Get an order" || op.Deprecated {
+		t.Errorf("Unexpected operation: %+v", op)
+	}
+	if !reflect.DeepEqual(op.Accept, []string{"application/json"}) || !reflect.DeepEqual(op.Produce, []string{"application/json", "text/xml"}) {
+		t.Errorf("Unexpected MIME types: accept %v, produce %v", op.Accept, op.Produce)
+	}
+	expectedSecurity := []SecurityRequirement{
+		{{Name: "ApiKeyAuth"}},
+		{{Name: "OAuth2", Scopes: []string{"read", "write"}}},
+	}
+	if !reflect.DeepEqual(op.Security, expectedSecurity) {
+		t.Errorf("Expected security %+v, got %+v", expectedSecurity, op.Security)
+	}
+	if len(op.Responses) != 3 || op.Responses[0].Failure || !op.Responses[1].Failure || op.Responses[2].Status != "500" {
+		t.Errorf("Unexpected responses: %+v", op.Responses)
+	}
+	var headerNames []string
+	for _, header := range op.Responses[0].Headers {
+		headerNames = append(headerNames, header.Name)
+	}
+	if !reflect.DeepEqual(headerNames, []string{"ETag", "X-Request-ID"}) || len(op.Responses[1].Headers) != 1 {
+		t.Errorf("Unexpected response headers: %+v", op.Responses)
+	}
+	legacy := model.Operations[1]
+	if !legacy.Deprecated || len(legacy.Security) != 1 || len(legacy.Security[0]) != 2 {
+		t.Errorf("Unexpected legacy operation: %+v", legacy)
+	}
+
+	// Endpoint information
+	var order TypeScriptType
+	for _, typ := range model.Types {
+		if typ.Name == "Order" {
+			order = typ
+		}
+	}
+	expectedEndpoint := EndpointInfo{
+		Method:      "get",
+		Path:        "/orders/{id}",
+		Response:    true,
+		Status:      "200",
+		Summary:     "Get an order",
+		OperationID: "getOrder",
+		Tags:        []string{"orders"},
+	}
+	if len(order.Endpoints) != 1 || !reflect.DeepEqual(order.Endpoints[0], expectedEndpoint) {
+		t.Errorf("Expected endpoint %+v, got %+v", expectedEndpoint, order.Endpoints)
+	}
+
+	// JSDoc
+	tsFilePath := filepath.Join(tempDir, "generated.ts")
+	if err := WriteTypeScript(model, tsFilePath, DefaultOptions()); err != nil {
+		t.Fatalf("WriteTypeScript failed: %v", err)
+	}
+	tsContent, err := os.ReadFile(tsFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read generated TypeScript file: %v", err)
+	}
+	tsContentStr := string(tsContent)
+	for _, expected := range []string{
+		" * - get /orders/{id} (Response 200): Get an order\n * @see getOrder (get /orders/{id})\n */\nexport interface Order",
+		" * - get /legacy/orders/{id} (Response 200): Get an order (legacy) (deprecated)\n * @see getLegacyOrder (get /legacy/orders/{id})\n * @deprecated Only used by deprecated endpoints\n */\nexport interface LegacyOrder",
+		" * - get /orders/{id} (Response 404, 500): Get an order\n * - get /legacy/orders/{id} (Response 404): Get an order (legacy) (deprecated)\n",
+	} {
+		if !strings.Contains(tsContentStr, expected) {
+			t.Errorf("Expected TypeScript to contain %q, got:\n%s", expected, tsContentStr)
+		}
+	}
+	if strings.Contains(tsContentStr, "@deprecated Only used by deprecated endpoints\n */\nexport interface ErrorResponse") {
+		t.Errorf("ErrorResponse should not be deprecated:\n%s", tsContentStr)
+	}
+
+	// OpenAPI document
+	var buf bytes.Buffer
+	if err := GenerateOpenAPIJSON(model, &buf); err != nil {
+		t.Fatalf("GenerateOpenAPIJSON failed: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Generated document is not valid JSON: %v", err)
+	}
+	get := func(value any, keys ...string) any {
+		for _, key := range keys {
+			value = value.(map[string]any)[key]
+		}
+		return value
+	}
+	getOrder := get(doc, "paths", "/orders/{id}", "get")
+	getLegacyOrder := get(doc, "paths", "/legacy/orders/{id}", "get")
+
+	tests := []struct {
+		name     string
+		actual   any
+		expected string
+	}{
+		{"operationId", get(getOrder, "operationId"), `"getOrder"`},
+		{"security alternatives", get(getOrder, "security"), `[{"ApiKeyAuth":[]},{"OAuth2":["read","write"]}]`},
+		{"security combination", get(getLegacyOrder, "security"), `[{"ApiKeyAuth":[],"OAuth2":["read"]}]`},
+		{"deprecated", get(getLegacyOrder, "deprecated"), `true`},
+		{"not deprecated", get(getOrder, "deprecated"), `null`},
+		{"response headers", get(getOrder, "responses", "200", "headers"), `{
+			"ETag":{"description":"Entity tag","schema":{"type":"string"}},
+			"X-Request-ID":{"description":"Request ID","schema":{"type":"string"}}
+		}`},
+		{"produce", get(getOrder, "responses", "404", "content"), `{
+			"application/json":{"schema":{"$ref":"#/components/schemas/ErrorResponse"}},
+			"text/xml":{"schema":{"$ref":"#/components/schemas/ErrorResponse"}}
+		}`},
+		{"failure", get(getOrder, "responses", "500", "description"), `"Error"`},
+		{"security schemes", get(doc, "components", "securitySchemes"), `{
+			"ApiKeyAuth":{"type":"apiKey","in":"header","name":"Authorization","description":"Bearer token"},
+			"OAuth2":{"type":"oauth2","flows":{"authorizationCode":{
+				"authorizationUrl":"https://example.com/oauth/authorize",
+				"tokenUrl":"https://example.com/oauth/token",
+				"scopes":{"read":"Grants read access","write":"Grants write access"}
+			}}}
+		}`},
+		{"info", doc["info"], `{"title":"Orders API","version":"1.0"}`},
+	}
+
+	for _, tt := range tests {
+		var expected any
+		if err := json.Unmarshal([]byte(tt.expected), &expected); err != nil {
+			t.Fatalf("%s: invalid expected JSON: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(tt.actual, expected) {
+			actual, _ := json.Marshal(tt.actual)
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.expected, actual)
+		}
+	}
+}
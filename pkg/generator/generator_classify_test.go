@@ -0,0 +1,164 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestClassifyAPITypes tests that API types are classified from swagger, handler and annotation signals
+func TestClassifyAPITypes(t *testing.T) {
+	// Create a temporary directory with a path that used to trigger the name heuristics
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-rapid-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with types used in different ways
+	goFilePath := filepath.Join(tempDir, "handlers.go")
+	goFileContent := `package rapid
+
+// Paragraph is a plain model type
+type Paragraph struct {
+	Text string ` + "`json:\"text\"`" + `
+}
+
+// CreatePostRequest is bound in a handler
+type CreatePostRequest struct {
+	Title  string ` + "`json:\"title\"`" + `
+	Author Author ` + "`json:\"author\"`" + `
+}
+
+// Author is referenced by an API type
+type Author struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// PostResponse is referenced from a swagger annotation
+type PostResponse struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+
+// User is returned without braces in the annotation
+type User struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// ErrorResponse is returned by failures
+type ErrorResponse struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+
+// Webhook is explicitly annotated
+//
+//ts:api
+type Webhook struct {
+	URL string ` + "`json:\"url\"`" + `
+}
+
+// GetPost godoc
+// @Success 200 {object} PostResponse
+// @Router /posts/{id} [get]
+func GetPost() {}
+
+// GetUser godoc
+// @Success 200 User
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id} [get]
+func GetUser() {}
+
+func CreatePost(c *Context) {
+	var req CreatePostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return
+	}
+}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	// Collect and classify types, then write the report
+	model, err := CollectModel([]string{tempDir}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+	types := model.Types
+	var report bytes.Buffer
+	if err := WriteClassificationReport(&report, types); err != nil {
+		t.Fatalf("WriteClassificationReport failed: %v", err)
+	}
+
+	expected := map[string]bool{
+		"Paragraph":         false,
+		"CreatePostRequest": true,
+		"Author":            true,
+		"PostResponse":      true,
+		"Webhook":           true,
+		"User":              true,
+		"ErrorResponse":     true,
+	}
+	for _, typ := range types {
+		if want, ok := expected[typ.Name]; ok && typ.IsAPIType != want {
+			t.Errorf("Expected IsAPIType=%v for %s, got %v (reasons: %v)", want, typ.Name, typ.IsAPIType, typ.APIReasons)
+		}
+	}
+
+	// Check the report shows the reasons
+	reportStr := report.String()
+	for _, reason := range []string{
+		"handler: bound in CreatePost (ShouldBindJSON)",
+		"swagger: @Success on get /posts/{id}",
+		"swagger: @Success on get /users/{id}",
+		"swagger: @Failure on get /users/{id}",
+		"referenced by CreatePostRequest",
+		"annotated with //ts:api",
+	} {
+		if !strings.Contains(reportStr, reason) {
+			t.Errorf("Classification report does not contain %q", reason)
+			t.Logf("Report:\n%s", reportStr)
+		}
+	}
+
+	// Disabling transitive classification leaves referenced types as model types
+	opts := DefaultOptions()
+	opts.APIRules.Transitive = false
+	model, err = CollectModel([]string{tempDir}, opts)
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+	types = model.Types
+	for _, typ := range types {
+		if typ.Name == "Author" && typ.IsAPIType {
+			t.Errorf("Expected Author not to be an API type without transitive classification")
+		}
+	}
+
+	// Write API types to a separate file
+	tsFilePath := filepath.Join(tempDir, "models.ts")
+	opts = DefaultOptions()
+	opts.APITypesFile = filepath.Join(tempDir, "api.ts")
+	if err := GenerateTypesWithOptions([]string{tempDir}, tsFilePath, opts); err != nil {
+		t.Fatalf("GenerateTypesWithOptions failed: %v", err)
+	}
+
+	modelContent, err := os.ReadFile(tsFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read generated TypeScript file: %v", err)
+	}
+	apiContent, err := os.ReadFile(opts.APITypesFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated API TypeScript file: %v", err)
+	}
+
+	if !strings.Contains(string(modelContent), "export interface Paragraph {") || strings.Contains(string(modelContent), "export interface PostResponse {") {
+		t.Errorf("Model file does not contain only model types:\n%s", modelContent)
+	}
+	if !strings.Contains(string(apiContent), "export interface PostResponse {") || strings.Contains(string(apiContent), "export interface Paragraph {") {
+		t.Errorf("API file does not contain only API types:\n%s", apiContent)
+	}
+}
@@ -0,0 +1,145 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestCompositeResponses tests swag's composite response syntax Envelope{data=[]User}
+func TestCompositeResponses(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-composite-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with composite responses
+	goFilePath := filepath.Join(tempDir, "api.go")
+	goFileContent := `package api
+
+// Envelope wraps every response
+type Envelope struct {
+	Code int         ` + "`json:\"code\"`" + `
+	Data interface{} ` + "`json:\"data\"`" + `
+	Meta interface{} ` + "`json:\"meta,omitempty\"`" + `
+}
+
+// Page is a page of results
+type Page struct {
+	Items interface{} ` + "`json:\"items\"`" + `
+	Total int         ` + "`json:\"total\"`" + `
+}
+
+// User represents a user
+type User struct {
+	ID int64 ` + "`json:\"id\"`" + `
+}
+
+// ListUsers godoc
+// @Success 200 {object} response.Envelope{data=[]User}
+// @Router /users [get]
+func ListUsers() {}
+
+// SearchUsers godoc
+// @Success 200 {object} response.Envelope{data=response.Page{items=[]User},meta=string}
+// @Router /users/search [get]
+func SearchUsers() {}
+
+// GetUser godoc
+// @Success 200 {object} Envelope{data=User}
+// @Router /users/{id} [get]
+func GetUser() {}
+
+// ListUsersV2 godoc
+// @Success 200 {array} Envelope{data=User}
+// @Router /v2/users [get]
+func ListUsersV2() {}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	// The swag type expression is parsed into a structured TypeRef
+	ref := swagTypeRef("response.Envelope{data=response.Page{items=[]User},meta=string}")
+	expectedRef := &TypeRef{Kind: KindComposite, Name: "Envelope", Overrides: []TypeOverride{
+		{Name: "data", Type: &TypeRef{Kind: KindComposite, Name: "Page", Overrides: []TypeOverride{
+			{Name: "items", Type: &TypeRef{Kind: KindArray, Elem: namedRef("User")}},
+		}}},
+		{Name: "meta", Type: primitiveRef("string")},
+	}}
+	if !reflect.DeepEqual(ref, expectedRef) {
+		t.Errorf("Unexpected TypeRef: %+v", ref)
+	}
+
+	opts := DefaultOptions()
+	model, err := CollectModel([]string{tempDir}, opts)
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+
+	// Composite responses are recorded as named endpoint response types
+	responseTypes := make(map[string]string)
+	for _, op := range model.Operations {
+		responseTypes[op.Path] = op.Responses[0].Type.String()
+	}
+	expectedResponseTypes := map[string]string{
+		"/users":        "EnvelopeUserList",
+		"/users/search": "EnvelopePageUserListString",
+		"/users/{id}":   "EnvelopeUser",
+		"/v2/users":     "EnvelopeUser[]",
+	}
+	if !reflect.DeepEqual(responseTypes, expectedResponseTypes) {
+		t.Errorf("Expected response types %v, got %v", expectedResponseTypes, responseTypes)
+	}
+
+	// The types of the composite are linked as API types
+	for _, typ := range model.Types {
+		if !typ.IsAPIType {
+			t.Errorf("Expected %s to be an API type", typ.Name)
+		}
+	}
+
+	tsFilePath := filepath.Join(tempDir, "generated.ts")
+	if err := WriteTypeScript(model, tsFilePath, opts); err != nil {
+		t.Fatalf("WriteTypeScript failed: %v", err)
+	}
+	tsContent, err := os.ReadFile(tsFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read generated TypeScript file: %v", err)
+	}
+	tsContentStr := string(tsContent)
+
+	for _, expected := range []string{
+		"export type EnvelopeUserList = Omit<Envelope, \"data\"> & { data: User[] };",
+		"export type EnvelopePageUserListString = Omit<Envelope, \"data\" | \"meta\"> & { data: Omit<Page, \"items\"> & { items: User[] }; meta: string };",
+		"export type EnvelopeUser = Omit<Envelope, \"data\"> & { data: User };",
+		" * - get /users/{id} (Response 200)\n * - get /v2/users (Response 200)\n */\nexport type EnvelopeUser =",
+	} {
+		if !strings.Contains(tsContentStr, expected) {
+			t.Errorf("Expected TypeScript to contain %q, got:\n%s", expected, tsContentStr)
+		}
+	}
+
+	// The OpenAPI document references the alias, which combines the base and the overrides
+	var buf bytes.Buffer
+	if err := GenerateOpenAPIJSON(model, &buf); err != nil {
+		t.Fatalf("GenerateOpenAPIJSON failed: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Generated document is not valid JSON: %v", err)
+	}
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+	actual, _ := json.Marshal(schemas["EnvelopeUserList"])
+	expected := `{"allOf":[{"$ref":"#/components/schemas/Envelope"},{"properties":{"data":{"items":{"$ref":"#/components/schemas/User"},"type":"array"}},"required":["data"],"type":"object"}],"description":"EnvelopeUserList is Envelope with data: User[]"}`
+	if string(actual) != expected {
+		t.Errorf("Expected schema %s, got %s", expected, actual)
+	}
+}
@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerateDateTypes tests time.Time fields rendered as Date with generated conversion helpers
+func TestGenerateDateTypes(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-dates-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with nested date fields
+	goFilePath := filepath.Join(tempDir, "date_models.go")
+	goFileContent := `package dates
+
+import "time"
+
+// Event represents a calendar event
+type Event struct {
+	ID        int                  ` + "`json:\"id\"`" + `
+	StartsAt  time.Time            ` + "`json:\"starts_at\"`" + `
+	EndsAt    *time.Time           ` + "`json:\"ends_at,omitempty\"`" + `
+	Reminders []time.Time          ` + "`json:\"reminders\"`" + `
+	ByZone    map[string]time.Time ` + "`json:\"by_zone\"`" + `
+	Owner     *User                ` + "`json:\"owner\"`" + `
+	Guests    []*User              ` + "`json:\"guests\"`" + `
+}
+
+// User represents an event participant
+type User struct {
+	Name     string    ` + "`json:\"name\"`" + `
+	JoinedAt time.Time ` + "`json:\"joined_at\"`" + `
+}
+
+// Stats is keyed by day, and map keys stay strings
+type Stats struct {
+	ByDay map[time.Time]int ` + "`json:\"by_day\"`" + `
+}
+
+// Tag has no date fields
+type Tag struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	// Generate TypeScript types in Date mode
+	tsFilePath := filepath.Join(tempDir, "generated.ts")
+	opts := DefaultOptions()
+	opts.TimeAsDate = true
+	if err := GenerateTypesWithOptions([]string{tempDir}, tsFilePath, opts); err != nil {
+		t.Fatalf("GenerateTypesWithOptions failed: %v", err)
+	}
+
+	// Read the generated TypeScript file
+	tsContent, err := os.ReadFile(tsFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read generated TypeScript file: %v", err)
+	}
+
+	// Check for expected content
+	tsContentStr := string(tsContent)
+
+	for _, expected := range []string{
+		"starts_at: Date;",
+		"ends_at?: Date | null;",
+		"reminders: Date[];",
+		"by_zone: Record<string, Date>;",
+		"export function parseEvent(json: any): Event {",
+		"    starts_at: new Date(json.starts_at),",
+		"    ends_at: json.ends_at == null ? json.ends_at : new Date(json.ends_at),",
+		"    reminders: json.reminders == null ? json.reminders : json.reminders.map((v0: any) => new Date(v0)),",
+		"    by_zone: json.by_zone == null ? json.by_zone : mapRecord(json.by_zone, (v0: any) => new Date(v0)),",
+		"    owner: json.owner == null ? json.owner : parseUser(json.owner),",
+		"    guests: json.guests == null ? json.guests : json.guests.map((v0: any) => v0 == null ? v0 : parseUser(v0)),",
+		"export function serializeEvent(value: Event): any {",
+		"    starts_at: value.starts_at.toISOString(),",
+		"export function parseUser(json: any): User {",
+		"export function serializeUser(value: User): any {",
+	} {
+		if !strings.Contains(tsContentStr, expected) {
+			t.Errorf("Generated TypeScript does not contain %q", expected)
+			t.Logf("Generated TypeScript:\n%s", tsContentStr)
+		}
+	}
+
+	// Types without date fields do not need helpers
+	if strings.Contains(tsContentStr, "parseTag") || strings.Contains(tsContentStr, "parseStats") {
+		t.Errorf("Generated TypeScript contains a helper for a type without date fields")
+	}
+	// Map keys are strings in JSON and keep their hint
+	if !strings.Contains(tsContentStr, "by_day: Record<string /* RFC3339 */, number>;") {
+		t.Errorf("Generated TypeScript does not keep time.Time map keys as strings")
+	}
+	if strings.Contains(strings.ReplaceAll(tsContentStr, "Record<string /* RFC3339 */,", ""), "RFC3339") {
+		t.Errorf("Generated TypeScript still contains RFC3339 strings in Date mode")
+	}
+}
+
+// TestDateUnionConversion tests the conversion of union values referenced from fields, selected
+// by the discriminator of the members
+func TestDateUnionConversion(t *testing.T) {
+	dateRef := &TypeRef{Kind: KindPrimitive, Name: "Date", GoType: "time.Time"}
+	union := &TypeRef{Kind: KindUnion, Discriminator: "kind", Members: []*TypeRef{namedRef("Circle"), namedRef("Square")}}
+	types := []TypeScriptType{
+		{Name: "Circle", IsInterface: true, Fields: []TypeScriptField{
+			{Name: "kind", TypeRef: primitiveRef(`"circle"`)},
+			{Name: "drawn_at", TypeRef: dateRef},
+		}},
+		{Name: "Square", IsInterface: true, Fields: []TypeScriptField{
+			{Name: "kind", TypeRef: primitiveRef(`"square"`)},
+			{Name: "side", TypeRef: primitiveRef("number")},
+		}},
+		{Name: "Drawing", IsInterface: true, Fields: []TypeScriptField{
+			{Name: "main", TypeRef: union},
+			{Name: "shapes", TypeRef: &TypeRef{Kind: KindArray, Elem: union}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	writeDateHelpers(&buf, types, types, dateTypeNames(types))
+	content := buf.String()
+	for _, expected := range []string{
+		"export function parseDrawing(json: any): Drawing {",
+		`    main: json.main.kind === "circle" ? parseCircle(json.main) : json.main,`,
+		`    shapes: json.shapes == null ? json.shapes : json.shapes.map((v0: any) => v0.kind === "circle" ? parseCircle(v0) : v0),`,
+		`    main: value.main.kind === "circle" ? serializeCircle(value.main) : value.main,`,
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected the date helpers to contain %q, got:\n%s", expected, content)
+		}
+	}
+	if strings.Contains(content, "parseSquare") {
+		t.Errorf("Expected no helper for a member without date fields, got:\n%s", content)
+	}
+}
@@ -0,0 +1,172 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestDiffModels tests the changes and breaking changes detected between two models
+func TestDiffModels(t *testing.T) {
+	// Create temporary directories for the old and new versions
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-diff-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	oldContent := `package api
+
+// CreateUserRequest is the payload to create a user
+type CreateUserRequest struct {
+	Name  string ` + "`json:\"name\"`" + `
+	Email string ` + "`json:\"email,omitempty\"`" + `
+	Role  string ` + "`json:\"role\" validate:\"oneof=admin user guest\"`" + `
+	Age   int    ` + "`json:\"age\"`" + `
+}
+
+// UserResponse is returned by the user endpoints
+type UserResponse struct {
+	ID       int64  ` + "`json:\"id\"`" + `
+	Name     string ` + "`json:\"name\"`" + `
+	Nickname string ` + "`json:\"nickname\"`" + `
+	Status   string ` + "`json:\"status\" validate:\"oneof=active\"`" + `
+}
+
+// CreateUser godoc
+// @Param user body CreateUserRequest true "User"
+// @Success 201 {object} UserResponse
+// @Router /users [post]
+func CreateUser() {}
+
+// DeleteUser godoc
+// @Success 204
+// @Router /users/{id} [delete]
+func DeleteUser() {}
+`
+	newContent := `package api
+
+// CreateUserRequest is the payload to create a user
+type CreateUserRequest struct {
+	Name  string  ` + "`json:\"name\"`" + `
+	Email string  ` + "`json:\"email\"`" + `
+	Role  string  ` + "`json:\"role\" validate:\"oneof=admin user\"`" + `
+	Age   int     ` + "`json:\"age\"`" + `
+	Team  *string ` + "`json:\"team,omitempty\"`" + `
+}
+
+// UserResponse is returned by the user endpoints
+type UserResponse struct {
+	ID       int64   ` + "`json:\"id\"`" + `
+	Nickname *string ` + "`json:\"nickname\"`" + `
+	Status   string  ` + "`json:\"status\" validate:\"oneof=active disabled\"`" + `
+}
+
+// CreateUser godoc
+// @Param user body CreateUserRequest true "User"
+// @Success 201 {object} UserResponse
+// @Router /users [post]
+func CreateUser() {}
+`
+
+	models := make([]*Model, 2)
+	for i, content := range []string{oldContent, newContent} {
+		dir := filepath.Join(tempDir, []string{"old", "new"}[i])
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "api.go"), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test Go file: %v", err)
+		}
+		model, err := CollectModel([]string{dir}, DefaultOptions())
+		if err != nil {
+			t.Fatalf("CollectModel failed: %v", err)
+		}
+		models[i] = model
+	}
+
+	changes := DiffModels(models[0], models[1])
+	if !HasBreakingChanges(changes) {
+		t.Errorf("Expected breaking changes, got %v", changes)
+	}
+
+	tests := []struct {
+		kind     ChangeKind
+		typ      string
+		field    string
+		breaking bool
+	}{
+		{ChangeOptionalToRequired, "CreateUserRequest", "email", true},
+		{ChangeEnumMemberRemoved, "CreateUserRequest", "role", true},
+		{ChangeFieldAdded, "CreateUserRequest", "team", false},
+		{ChangeFieldRemoved, "UserResponse", "name", true},
+		{ChangeNullableAdded, "UserResponse", "nickname", true},
+		{ChangeEnumMemberAdded, "UserResponse", "status", true},
+		{ChangeEndpointRemoved, "", "", true},
+	}
+	for _, tt := range tests {
+		found := false
+		for _, change := range changes {
+			if change.Kind == tt.kind && change.Type == tt.typ && change.Field == tt.field {
+				found = true
+				if change.Breaking != tt.breaking {
+					t.Errorf("Expected %s to have breaking=%v", change, tt.breaking)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("Expected a %s change of %s.%s, got %v", tt.kind, tt.typ, tt.field, changes)
+		}
+	}
+	for _, change := range changes {
+		if change.Field == "age" || change.Field == "id" {
+			t.Errorf("Expected no change of unchanged fields, got %s", change)
+		}
+	}
+
+	// Identical models have no changes
+	if same := DiffModels(models[1], models[1]); len(same) != 0 {
+		t.Errorf("Expected no changes between identical models, got %v", same)
+	}
+
+	var report bytes.Buffer
+	if err := WriteDiffReport(&report, changes); err != nil {
+		t.Fatalf("WriteDiffReport failed: %v", err)
+	}
+	for _, expected := range []string{"Breaking changes (", "  - field-removed: UserResponse.name", "  - endpoint-removed: DELETE /users/{id}"} {
+		if !strings.Contains(report.String(), expected) {
+			t.Errorf("Expected the report to contain %q, got:\n%s", expected, report.String())
+		}
+	}
+
+	var output bytes.Buffer
+	if err := WriteDiffJSON(&output, changes); err != nil {
+		t.Fatalf("WriteDiffJSON failed: %v", err)
+	}
+	var decoded struct {
+		Breaking int      `json:"breaking"`
+		Changes  []Change `json:"changes"`
+	}
+	if err := json.Unmarshal(output.Bytes(), &decoded); err != nil {
+		t.Fatalf("Failed to parse the JSON report: %v", err)
+	}
+	if len(decoded.Changes) != len(changes) || decoded.Breaking == 0 {
+		t.Errorf("Expected %d changes in the JSON report, got %s", len(changes), output.String())
+	}
+
+	// Saved models compare like the collected ones
+	var saved bytes.Buffer
+	if err := SaveModel(models[0], &saved); err != nil {
+		t.Fatalf("SaveModel failed: %v", err)
+	}
+	loaded, err := LoadModel(&saved)
+	if err != nil {
+		t.Fatalf("LoadModel failed: %v", err)
+	}
+	if reloaded := DiffModels(loaded, models[1]); len(reloaded) != len(changes) {
+		t.Errorf("Expected %d changes from the saved model, got %v", len(changes), reloaded)
+	}
+}
@@ -0,0 +1,200 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeTestFiles writes files given by slash-separated paths relative to dir
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+// TestPackageTargets tests the outputs declared with //tsgen:out directives by a package
+func TestPackageTargets(t *testing.T) {
+	// Create a temporary module with several packages
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-directives-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	writeTestFiles(t, tempDir, map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.21\n",
+		"users/users.go": `package users
+
+//go:generate go-ts-generator
+//tsgen:out ../web/types.ts
+//tsgen:out openapi=../docs/api.yaml
+
+// User is a user
+type User struct{}
+`,
+		// Directives in other files of the package are read too, once
+		"users/roles.go": "package users\n\n//tsgen:out ../web/types.ts\ntype Role string\n",
+		// Files of another package in the directory are left out with the package name
+		"users/gen.go":                "//go:build ignore\n\npackage main\n\n//tsgen:out ../web/gen.ts\n",
+		"users/users_test.go":         "package users\n\n//tsgen:out ../web/test.ts\n",
+		"orders/orders.go":            "package orders\n\n//tsgen:out ../web/types.ts\ntype Order struct{}\n",
+		"empty/empty.go":              "package empty\n\n//tsgen:out\n",
+		"orders/internal/internal.go": "package internal\n\n// Not a directive: //tsgen:out ../../web/types.ts\ntype Internal struct{}\n",
+	})
+
+	targets, err := PackageTargets(filepath.Join(tempDir, "users"), "users")
+	if err != nil {
+		t.Fatalf("PackageTargets failed: %v", err)
+	}
+	expected := []OutputTarget{
+		{Renderer: "ts", Path: filepath.FromSlash("../web/types.ts")},
+		{Renderer: "openapi", Path: filepath.FromSlash("../docs/api.yaml")},
+	}
+	if !reflect.DeepEqual(targets, expected) {
+		t.Errorf("Expected targets %+v, got %+v", expected, targets)
+	}
+
+	// Without a package name, every package in the directory is read
+	targets, err = PackageTargets(filepath.Join(tempDir, "users"), "")
+	if err != nil {
+		t.Fatalf("PackageTargets failed: %v", err)
+	}
+	if len(targets) != 3 || targets[0].Path != filepath.FromSlash("../web/gen.ts") {
+		t.Errorf("Expected the targets of both packages, got %+v", targets)
+	}
+
+	// Packages without directives have no targets
+	targets, err = PackageTargets(filepath.Join(tempDir, "orders", "internal"), "internal")
+	if err != nil {
+		t.Fatalf("PackageTargets failed: %v", err)
+	}
+	if len(targets) != 0 {
+		t.Errorf("Expected no targets, got %+v", targets)
+	}
+
+	if _, err := PackageTargets(filepath.Join(tempDir, "empty"), "empty"); err == nil {
+		t.Error("Expected an error for a directive without an output file")
+	}
+}
+
+// TestMergeTargetModel tests merging the models of the packages declaring the same output
+func TestMergeTargetModel(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-merge-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	writeTestFiles(t, tempDir, map[string]string{
+		"users/users.go":   "package users\n\n//tsgen:out ../web/types.ts\ntype User struct{}\n",
+		"orders/orders.go": "package orders\n\n//tsgen:out ../web/types.ts\ntype Order struct{}\n",
+	})
+	target := OutputTarget{Renderer: "ts", Path: filepath.FromSlash("../web/types.ts")}
+	users := &Model{
+		Types:      []TypeScriptType{{Name: "User"}, {Name: "Address"}},
+		Operations: []Operation{{Method: "get", Path: "/users"}},
+	}
+	orders := &Model{
+		Types:      []TypeScriptType{{Name: "Order"}, {Name: "Address"}},
+		Operations: []Operation{{Method: "get", Path: "/orders"}, {Method: "get", Path: "/users"}},
+		Info:       APIInfo{Title: "Shop"},
+	}
+	names := func(model *Model) []string {
+		var names []string
+		for _, t := range model.Types {
+			names = append(names, t.Name)
+		}
+		return names
+	}
+
+	cacheDir := filepath.Join(tempDir, "cache")
+	merged, err := MergeTargetModel(cacheDir, filepath.Join(tempDir, "users"), target, users)
+	if err != nil {
+		t.Fatalf("MergeTargetModel failed: %v", err)
+	}
+	if got := names(merged); !reflect.DeepEqual(got, []string{"User", "Address"}) {
+		t.Errorf("Expected the users types, got %v", got)
+	}
+
+	// The cache is written to the cache directory, not next to the output
+	cached, err := filepath.Glob(filepath.Join(cacheDir, "types.ts-*.json"))
+	if err != nil || len(cached) != 1 {
+		t.Errorf("Expected one cache file in the cache directory, got %v (%v)", cached, err)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "web")); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing written next to the output, got %v", err)
+	}
+
+	// The orders package is generated separately and the output holds both packages, in path
+	// order, with types and operations declared twice kept once
+	merged, err = MergeTargetModel(cacheDir, filepath.Join(tempDir, "orders"), target, orders)
+	if err != nil {
+		t.Fatalf("MergeTargetModel failed: %v", err)
+	}
+	if got := names(merged); !reflect.DeepEqual(got, []string{"Order", "Address", "User"}) {
+		t.Errorf("Expected the types of both packages, got %v", got)
+	}
+	if len(merged.Operations) != 2 || merged.Info.Title != "Shop" {
+		t.Errorf("Expected two operations and the API information, got %+v and %+v", merged.Operations, merged.Info)
+	}
+
+	// Packages no longer declaring the output are removed from the cache
+	writeTestFiles(t, tempDir, map[string]string{"orders/orders.go": "package orders\n\ntype Order struct{}\n"})
+	merged, err = MergeTargetModel(cacheDir, filepath.Join(tempDir, "users"), target, users)
+	if err != nil {
+		t.Fatalf("MergeTargetModel failed: %v", err)
+	}
+	if got := names(merged); !reflect.DeepEqual(got, []string{"User", "Address"}) {
+		t.Errorf("Expected only the users types, got %v", got)
+	}
+}
+
+// TestCollectPackage tests collecting the files of a single package, as go generate does
+func TestCollectPackage(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-package-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	writeTestFiles(t, tempDir, map[string]string{
+		"users/users.go":       "package users\n\n// User is a user\ntype User struct{}\n",
+		"users/gen.go":         "//go:build ignore\n\npackage main\n\n// Generator is built by go run\ntype Generator struct{}\n",
+		"users/admin/admin.go": "package admin\n\n// Admin is in a subpackage\ntype Admin struct{}\n",
+	})
+	names := func(model *Model) map[string]bool {
+		names := make(map[string]bool)
+		for _, t := range model.Types {
+			names[t.Name] = true
+		}
+		return names
+	}
+
+	opts := DefaultOptions()
+	opts.Package = "users"
+	model, err := CollectModel([]string{filepath.Join(tempDir, "users")}, opts)
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+	if got := names(model); !reflect.DeepEqual(got, map[string]bool{"User": true}) {
+		t.Errorf("Expected only the users package, got %v", got)
+	}
+
+	// Without a package, the subdirectories and every package are collected
+	model, err = CollectModel([]string{filepath.Join(tempDir, "users")}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+	if got := names(model); len(got) != 3 {
+		t.Errorf("Expected the types of the three packages, got %v", got)
+	}
+}
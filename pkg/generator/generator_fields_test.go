@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerateMultiNameFields tests that every name in a multi-name field declaration is emitted
+func TestGenerateMultiNameFields(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-fields-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with multi-name field declarations
+	goFilePath := filepath.Join(tempDir, "geometry.go")
+	goFileContent := `package geometry
+
+// Point represents a geographic coordinate
+type Point struct {
+	Lat, Lng float64
+}
+
+// Vector represents a 2D vector ignored by encoding/json
+type Vector struct {
+	X, Y float64 ` + "`json:\"-\"`" + `
+}
+
+// Delta has a property named "-", written json:"-,"
+type Delta struct {
+	Value int ` + "`json:\"-,\"`" + `
+}
+
+// Box represents a bounding box with mixed exported and unexported corners
+type Box struct {
+	Min, max *Point
+}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	// Generate TypeScript types
+	tsFilePath := filepath.Join(tempDir, "generated.ts")
+	if err := GenerateTypes(tempDir, tsFilePath); err != nil {
+		t.Fatalf("GenerateTypes failed: %v", err)
+	}
+
+	// Read the generated TypeScript file
+	tsContent, err := os.ReadFile(tsFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read generated TypeScript file: %v", err)
+	}
+
+	// Check for expected content
+	tsContentStr := string(tsContent)
+
+	for _, expected := range []string{
+		"  Lat: number;\n  Lng: number;",
+		"  Min: Point | null;",
+		"   * Note: This is an unexported field. In Go code, it's defined with a lowercase identifier.\n   * It cannot be accessed directly from outside the package.\n   */\n  max: Point | null;",
+		// json:"-," names the property "-"
+		`  "-": number;`,
+	} {
+		if !strings.Contains(tsContentStr, expected) {
+			t.Errorf("Generated TypeScript does not contain %q", expected)
+			t.Logf("Generated TypeScript:\n%s", tsContentStr)
+		}
+	}
+
+	// Fields tagged json:"-" are skipped by encoding/json
+	for _, unexpected := range []string{"  X: number;", "  Y: number;", "  Value: number;"} {
+		if strings.Contains(tsContentStr, unexpected) {
+			t.Errorf("Generated TypeScript should not contain %q", unexpected)
+		}
+	}
+}
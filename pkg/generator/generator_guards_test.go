@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerateGuards tests the runtime type guards generated from the collected types
+func TestGenerateGuards(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-guards-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with optional, nullable, array, map and nested fields
+	goFilePath := filepath.Join(tempDir, "models.go")
+	goFileContent := `package models
+
+import "time"
+
+// Status is the status of a message
+type Status string
+
+// Author is the author of a message
+type Author struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// Message is received over a WebSocket
+type Message struct {
+	ID        int64                ` + "`json:\"id\"`" + `
+	Text      string               ` + "`json:\"text,omitempty\"`" + `
+	Author    *Author              ` + "`json:\"author\"`" + `
+	ReplyTo   *int64               ` + "`json:\"reply_to,omitempty\"`" + `
+	Tags      []string             ` + "`json:\"tags\"`" + `
+	Reactions map[string][]*Author ` + "`json:\"reactions\"`" + `
+	Status    Status               ` + "`json:\"status\"`" + `
+	Payload   interface{}          ` + "`json:\"payload\"`" + `
+	SentAt    time.Time            ` + "`json:\"sent_at\"`" + `
+}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	model, err := CollectModel([]string{tempDir}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := GenerateGuards(model, &buf, "./types"); err != nil {
+		t.Fatalf("GenerateGuards failed: %v", err)
+	}
+	content := buf.String()
+
+	for _, expected := range []string{
+		`import type { Author, Message, Status } from "./types";`,
+		`export function isStatus(value: unknown): value is Status {
+  return typeof value === "string";
+}`,
+		`export function isAuthor(value: unknown): value is Author {
+  return (
+    isObject(value) &&
+    typeof value["name"] === "string"
+  );
+}`,
+		// Optional fields may be undefined, nullable fields null; nested types use their guards
+		`export function isMessage(value: unknown): value is Message {
+  return (
+    isObject(value) &&
+    typeof value["id"] === "number" &&
+    (value["text"] === undefined || typeof value["text"] === "string") &&
+    (value["author"] === null || isAuthor(value["author"])) &&
+    (value["reply_to"] === undefined || value["reply_to"] === null || typeof value["reply_to"] === "number") &&
+    (Array.isArray(value["tags"]) && value["tags"].every((item) => typeof item === "string")) &&
+    (isObject(value["reactions"]) && Object.values(value["reactions"]).every((item) => (Array.isArray(item) && item.every((item2) => (item2 === null || isAuthor(item2)))))) &&
+    isStatus(value["status"]) &&
+    typeof value["sent_at"] === "string"
+  );
+}`,
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected guards to contain %q, got:\n%s", expected, content)
+		}
+	}
+
+	// Dates are checked as Date objects with the Date mode
+	opts := DefaultOptions()
+	opts.TimeAsDate = true
+	model, err = CollectModel([]string{tempDir}, opts)
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+	buf.Reset()
+	if err := GenerateGuards(model, &buf, "./types"); err != nil {
+		t.Fatalf("GenerateGuards failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `value["sent_at"] instanceof Date`) {
+		t.Errorf("Expected a Date check, got:\n%s", buf.String())
+	}
+}
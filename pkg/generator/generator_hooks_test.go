@@ -0,0 +1,263 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerateHooks tests the TanStack Query and SWR hooks generated for the operations
+func TestGenerateHooks(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-hooks-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with annotated handlers
+	goFilePath := filepath.Join(tempDir, "api.go")
+	goFileContent := `package api
+
+// User represents a user
+type User struct {
+	ID int64 ` + "`json:\"id\"`" + `
+}
+
+// UpdateUserRequest is the payload to update a user
+type UpdateUserRequest struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// GetUser godoc
+// @ID getUser
+// @Summary Get a user
+// @Param room_id path integer true "Room ID"
+// @Param id path integer true "User ID"
+// @Success 200 {object} User
+// @Router /rooms/{room_id}/users/{id} [get]
+func GetUser() {}
+
+// ListUsers godoc
+// @Param name query string false "Name"
+// @Success 200 {array} User
+// @Router /users [get]
+func ListUsers() {}
+
+// UpdateUser godoc
+// @Param id path integer true "User ID"
+// @Param user body UpdateUserRequest true "User"
+// @Param notify query bool false "Notify the user"
+// @Success 200 {object} User
+// @Router /users/{id} [put]
+func UpdateUser() {}
+
+// @Success 204
+// @Router /sessions [delete]
+
+// ClearCache godoc
+// @ID delete
+// @Success 204
+// @Router /cache [delete]
+func ClearCache() {}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	model, err := CollectModel([]string{tempDir}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		library  HookLibrary
+		expected []string
+	}{
+		{
+			name:    "TanStack Query",
+			library: HooksTanStack,
+			expected: []string{
+				`import { useMutation, useQuery, type UseMutationOptions, type UseQueryOptions } from "@tanstack/react-query";
+import type { UpdateUserRequest, User } from "./types";`,
+				// Query keys start with the path segments
+				`export const queryKeys = {
+  getUser: (roomId: number, id: number) => ["rooms", roomId, "users", id] as const,
+  listUsers: (query?: { name?: string }) => ["users", query] as const,
+};`,
+				`/** Get a user (GET /rooms/{room_id}/users/{id}) */
+export function getUser(roomId: number, id: number): Promise<User> {
+  return apiFetcher<User>({ method: "GET", path: ` + "`/rooms/${encodeURIComponent(String(roomId))}/users/${encodeURIComponent(String(id))}`" + ` });
+}`,
+				`export function useGetUser(
+  roomId: number,
+  id: number,
+  options?: Omit<UseQueryOptions<User, Error, User, ReturnType<typeof queryKeys.getUser>>, "queryKey" | "queryFn">,
+) {
+  return useQuery({ queryKey: queryKeys.getUser(roomId, id), queryFn: () => getUser(roomId, id), ...options });
+}`,
+				`export function useListUsers(
+  query?: { name?: string },`,
+				// Mutations take the path parameters, body and query as variables
+				`export function updateUser(id: number, body: UpdateUserRequest, query?: { notify?: boolean }): Promise<User> {
+  return apiFetcher<User>({ method: "PUT", path: ` + "`/users/${encodeURIComponent(String(id))}`" + `, query, body });
+}`,
+				`export type UpdateUserVariables = { id: number; body: UpdateUserRequest; query?: { notify?: boolean } };
+
+export function useUpdateUser(options?: Omit<UseMutationOptions<User, Error, UpdateUserVariables>, "mutationFn">) {
+  return useMutation({ mutationFn: (variables: UpdateUserVariables) => updateUser(variables.id, variables.body, variables.query), ...options });
+}`,
+				// Operations without an ID or handler are named after their method and path
+				`export function useDeleteSessions(options?: Omit<UseMutationOptions<void, Error, void>, "mutationFn">) {
+  return useMutation({ mutationFn: () => deleteSessions(), ...options });
+}`,
+				// Operation IDs that are reserved words are suffixed
+				`export function deleteRequest(): Promise<void> {`,
+				"export function useDeleteRequest(",
+			},
+		},
+		{
+			name:    "SWR",
+			library: HooksSWR,
+			expected: []string{
+				`import useSWR, { type SWRConfiguration } from "swr";
+import useSWRMutation, { type SWRMutationConfiguration } from "swr/mutation";`,
+				`export function useGetUser(roomId: number, id: number, config?: SWRConfiguration<User, Error>) {
+  return useSWR(queryKeys.getUser(roomId, id), () => getUser(roomId, id), config);
+}`,
+				`export function useUpdateUser(config?: SWRMutationConfiguration<User, Error, string, UpdateUserVariables>) {
+  return useSWRMutation("PUT /users/{id}", (_key: string, { arg: variables }: { arg: UpdateUserVariables }) => updateUser(variables.id, variables.body, variables.query), config);
+}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := GenerateHooks(model, &buf, "./types", tt.library); err != nil {
+				t.Fatalf("GenerateHooks failed: %v", err)
+			}
+			content := buf.String()
+			for _, expected := range tt.expected {
+				if !strings.Contains(content, expected) {
+					t.Errorf("Expected hooks to contain %q, got:\n%s", expected, content)
+				}
+			}
+		})
+	}
+
+	if err := GenerateHooks(model, &bytes.Buffer{}, "./types", "unknown"); err == nil {
+		t.Error("Expected an error for an unknown hook library")
+	}
+}
+
+// TestGenerateHooksDates tests that the request functions convert the dates of bodies and
+// responses with the helpers of the types file in the Date mode
+func TestGenerateHooksDates(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-hooks-dates-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	goFileContent := `package api
+
+import "time"
+
+// Event is a scheduled event
+type Event struct {
+	Name     string    ` + "`json:\"name\"`" + `
+	StartsAt time.Time ` + "`json:\"starts_at\"`" + `
+}
+
+// User has no dates
+type User struct {
+	ID int64 ` + "`json:\"id\"`" + `
+}
+
+// GetEvent godoc
+// @Param id path integer true "Event ID"
+// @Success 200 {object} Event
+// @Router /events/{id} [get]
+func GetEvent() {}
+
+// ListEvents godoc
+// @Success 200 {array} Event
+// @Router /events [get]
+func ListEvents() {}
+
+// CreateEvent godoc
+// @Param event body Event true "Event"
+// @Success 201 {object} Event
+// @Router /events [post]
+func CreateEvent() {}
+
+// DeleteEvent godoc
+// @Param id path integer true "Event ID"
+// @Success 200 {object} Event
+// @Success 204
+// @Router /events/{id} [delete]
+func DeleteEvent() {}
+
+// GetUser godoc
+// @Success 200 {object} User
+// @Router /user [get]
+func GetUser() {}
+`
+	if err := os.WriteFile(filepath.Join(tempDir, "api.go"), []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	opts := DefaultOptions()
+	opts.TimeAsDate = true
+	model, err := CollectModel([]string{tempDir}, opts)
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := GenerateHooks(model, &buf, "./types", HooksTanStack); err != nil {
+		t.Fatalf("GenerateHooks failed: %v", err)
+	}
+	content := buf.String()
+	for _, expected := range []string{
+		`import type { Event, User } from "./types";
+import { parseEvent, serializeEvent } from "./types";`,
+		`export function getEvent(id: number): Promise<Event> {
+  return apiFetcher<any>({ method: "GET", path: ` + "`/events/${encodeURIComponent(String(id))}`" + ` }).then((json) => parseEvent(json));
+}`,
+		`export function listEvents(): Promise<Event[]> {
+  return apiFetcher<any>({ method: "GET", path: ` + "`/events`" + ` }).then((json) => json == null ? json : json.map((v0: any) => parseEvent(v0)));
+}`,
+		`export function createEvent(body: Event): Promise<Event> {
+  return apiFetcher<any>({ method: "POST", path: ` + "`/events`" + `, body: serializeEvent(body) }).then((json) => parseEvent(json));
+}`,
+		// Responses that may be empty are returned as decoded
+		`export function deleteEvent(id: number): Promise<Event | void> {
+  return apiFetcher<Event | void>(`,
+		// Types without dates are not converted
+		`  return apiFetcher<User>({ method: "GET", path: ` + "`/user`" + ` });`,
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected hooks to contain %q, got:\n%s", expected, content)
+		}
+	}
+
+	// The helpers are not imported without the Date mode
+	model, err = CollectModel([]string{tempDir}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+	buf.Reset()
+	if err := GenerateHooks(model, &buf, "./types", HooksTanStack); err != nil {
+		t.Fatalf("GenerateHooks failed: %v", err)
+	}
+	if strings.Contains(buf.String(), "parseEvent") {
+		t.Errorf("Expected no date helpers without the Date mode, got:\n%s", buf.String())
+	}
+}
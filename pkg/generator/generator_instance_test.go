@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestGenerator tests loading and rendering with a Generator, concurrently and with a
+// cancelled context
+func TestGenerator(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-instance-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with a user type
+	goFilePath := filepath.Join(tempDir, "api.go")
+	goFileContent := `package api
+
+// User is returned by the user endpoints
+type User struct {
+	ID       int64  ` + "`json:\"id\"`" + `
+	Nickname string
+}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	opts := DefaultOptions()
+	opts.PropertyNaming = NamingCamelCase
+	g := New(WithOptions(opts))
+
+	if g.Model() != nil {
+		t.Error("Expected no model before Load")
+	}
+	if err := g.Render(&bytes.Buffer{}); err == nil {
+		t.Error("Expected an error rendering before Load")
+	}
+
+	if err := g.Load(context.Background(), tempDir); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	model := g.Model()
+	if model == nil || len(model.Types) != 1 {
+		t.Fatalf("Expected the User type in the model, got %+v", model)
+	}
+
+	// Render, WriteFile, Model and Load can be called concurrently
+	var wg sync.WaitGroup
+	errs := make(chan error, 30)
+	for i := 0; i < 10; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			var buf bytes.Buffer
+			if err := g.Render(&buf); err != nil {
+				errs <- err
+			} else if !strings.Contains(buf.String(), "  nickname: string;") {
+				errs <- fmt.Errorf("expected the camelCase property, got:\n%s", buf.String())
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			if err := g.WriteFile(filepath.Join(tempDir, fmt.Sprintf("types%d.ts", i))); err != nil {
+				errs <- err
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			if err := g.Load(context.Background(), tempDir); err != nil {
+				errs <- err
+			}
+			if g.Model() == nil {
+				errs <- errors.New("expected a model after Load")
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	// A cancelled Load returns the context error and keeps the previous model
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	loaded := g.Model()
+	if err := g.Load(ctx, tempDir); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if g.Model() != loaded {
+		t.Error("Expected a cancelled Load to keep the previous model")
+	}
+}
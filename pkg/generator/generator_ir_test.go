@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestIntermediateRepresentation tests the source information in the model and the saved
+// intermediate representation
+func TestIntermediateRepresentation(t *testing.T) {
+	// Create a temporary module with an api package
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-ir-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte("module example.com/shop\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	apiDir := filepath.Join(tempDir, "api")
+	if err := os.MkdirAll(apiDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	goFilePath := filepath.Join(apiDir, "api.go")
+	goFileContent := `package api
+
+// Item is an item of an order
+type Item struct {
+	SKU string ` + "`json:\"sku\"`" + `
+}
+
+// Order is returned by the order endpoints
+type Order struct {
+	Items  []*Item           ` + "`json:\"items\"`" + `
+	Labels map[string]string ` + "`json:\"labels,omitempty\"`" + `
+}
+
+// Status is the status of an order
+type Status string
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	model, err := CollectModel([]string{apiDir}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+
+	typeMap := make(map[string]TypeScriptType)
+	for _, typ := range model.Types {
+		typeMap[typ.Name] = typ
+	}
+	order := typeMap["Order"]
+	if order.PackagePath != "example.com/shop/api" {
+		t.Errorf("Expected the package path example.com/shop/api, got %q", order.PackagePath)
+	}
+	if order.Position == nil || order.Position.Line != 9 || !strings.HasSuffix(order.Position.File, "api/api.go") {
+		t.Errorf("Expected Order to be declared at api/api.go:9, got %+v", order.Position)
+	}
+	if goType := order.Fields[0].GoType; goType != "[]*Item" {
+		t.Errorf("Expected the Go type []*Item, got %q", goType)
+	}
+	if position := order.Fields[1].Position; position == nil || position.Line != 11 || position.Column != 2 {
+		t.Errorf("Expected labels to be declared at line 11, column 2, got %+v", position)
+	}
+	if goType := typeMap["Status"].Fields[0].GoType; goType != "string" {
+		t.Errorf("Expected the Go type string for Status, got %q", goType)
+	}
+
+	var saved bytes.Buffer
+	if err := SaveModel(model, &saved); err != nil {
+		t.Fatalf("SaveModel failed: %v", err)
+	}
+	for _, expected := range []string{`"version": 1`, `"goType": "map[string]string"`, `"packagePath": "example.com/shop/api"`} {
+		if !strings.Contains(saved.String(), expected) {
+			t.Errorf("Expected the IR to contain %q, got:\n%s", expected, saved.String())
+		}
+	}
+
+	// Rendering from the loaded model gives the same output
+	loaded, err := LoadModel(bytes.NewReader(saved.Bytes()))
+	if err != nil {
+		t.Fatalf("LoadModel failed: %v", err)
+	}
+	var original, reloaded bytes.Buffer
+	if err := writeTypeScript(&original, model.Types, model.Types, nil, DefaultOptions()); err != nil {
+		t.Fatalf("writeTypeScript failed: %v", err)
+	}
+	if err := writeTypeScript(&reloaded, loaded.Types, loaded.Types, nil, DefaultOptions()); err != nil {
+		t.Fatalf("writeTypeScript failed: %v", err)
+	}
+	if original.String() != reloaded.String() {
+		t.Errorf("Expected the same TypeScript from the loaded model, got:\n%s\nwant:\n%s", reloaded.String(), original.String())
+	}
+
+	// Missing and newer versions are rejected
+	for _, doc := range []string{`{"types": []}`, `{"version": 99, "types": []}`} {
+		if _, err := LoadModel(strings.NewReader(doc)); err == nil {
+			t.Errorf("Expected an error loading %s", doc)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestGenerateJSONSchema tests the JSON Schema output of the collected types
+func TestGenerateJSONSchema(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-jsonschema-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with references, pointers and validation rules
+	goFilePath := filepath.Join(tempDir, "schema_models.go")
+	goFileContent := `package schema
+
+import "time"
+
+// Role is the role of a user
+type Role string
+
+// User represents a user
+type User struct {
+	ID        int64             ` + "`json:\"id\"`" + `
+	Name      string            ` + "`json:\"name\" validate:\"required,min=3,max=50\"`" + `
+	Email     string            ` + "`json:\"email\" binding:\"required,email\"`" + `
+	Age       int               ` + "`json:\"age,omitempty\" validate:\"gte=0,lt=150\"`" + `
+	Score     float64           ` + "`json:\"score\"`" + `
+	Role      Role              ` + "`json:\"role\" validate:\"oneof=admin member\"`" + `
+	Manager   *User             ` + "`json:\"manager\"`" + `
+	Nickname  *string           ` + "`json:\"nickname\"`" + `
+	Tags      []string          ` + "`json:\"tags\" validate:\"max=5,dive,min=1\"`" + `
+	Scores    map[int]float64   ` + "`json:\"scores\"`" + `
+	Avatar    []byte            ` + "`json:\"avatar\"`" + `
+	CreatedAt time.Time         ` + "`json:\"created_at\"`" + `
+	Extra     map[string]any    ` + "`json:\"extra,omitempty\"`" + `
+}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	// Date mode must not change the wire format described by the schema
+	opts := DefaultOptions()
+	opts.TimeAsDate = true
+	model, err := CollectModel([]string{tempDir}, opts)
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := GenerateJSONSchema(model, &buf); err != nil {
+		t.Fatalf("GenerateJSONSchema failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Generated schema is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if doc["$schema"] != JSONSchemaDialect {
+		t.Errorf("Expected $schema %q, got %v", JSONSchemaDialect, doc["$schema"])
+	}
+
+	defs := doc["$defs"].(map[string]any)
+	role := defs["Role"].(map[string]any)
+	user := defs["User"].(map[string]any)
+	properties := user["properties"].(map[string]any)
+
+	tests := []struct {
+		name     string
+		actual   any
+		expected string
+	}{
+		{"alias", role, `{"description":"Role is the role of a user","type":"string"}`},
+		{"integer", properties["id"], `{"type":"integer"}`},
+		{"string length", properties["name"], `{"type":"string","minLength":3,"maxLength":50}`},
+		{"binding format", properties["email"], `{"type":"string","format":"email"}`},
+		{"number bounds", properties["age"], `{"type":"integer","minimum":0,"exclusiveMaximum":150}`},
+		{"number", properties["score"], `{"type":"number"}`},
+		{"enum", properties["role"], `{"$ref":"#/$defs/Role","enum":["admin","member"]}`},
+		{"nullable reference", properties["manager"], `{"anyOf":[{"$ref":"#/$defs/User"},{"type":"null"}]}`},
+		{"nullable primitive", properties["nickname"], `{"type":["string","null"]}`},
+		{"dive", properties["tags"], `{"type":"array","items":{"type":"string","minLength":1},"maxItems":5}`},
+		{"integer keys", properties["scores"], `{"type":"object","propertyNames":{"pattern":"^-?[0-9]+$"},"additionalProperties":{"type":"number"}}`},
+		{"base64", properties["avatar"], `{"type":"string","contentEncoding":"base64"}`},
+		{"date-time", properties["created_at"], `{"type":"string","format":"date-time"}`},
+		{"any values", properties["extra"], `{"type":"object","additionalProperties":{}}`},
+		{"required", user["required"], `["id","name","email","score","role","manager","nickname","tags","scores","avatar","created_at"]`},
+	}
+
+	for _, tt := range tests {
+		var expected any
+		if err := json.Unmarshal([]byte(tt.expected), &expected); err != nil {
+			t.Fatalf("%s: invalid expected JSON: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(tt.actual, expected) {
+			actual, _ := json.Marshal(tt.actual)
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.expected, actual)
+		}
+	}
+
+	// The schema of a type keeps the declaration order of its fields
+	nameIndex := bytes.Index(buf.Bytes(), []byte(`"name"`))
+	emailIndex := bytes.Index(buf.Bytes(), []byte(`"email"`))
+	if nameIndex < 0 || emailIndex < nameIndex {
+		t.Errorf("Expected properties in declaration order:\n%s", buf.String())
+	}
+}
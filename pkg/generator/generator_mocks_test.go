@@ -0,0 +1,197 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerateMocks tests the mock factories generated from the collected types
+func TestGenerateMocks(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-mocks-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with constrained, nullable and recursive fields
+	goFilePath := filepath.Join(tempDir, "models.go")
+	goFileContent := `package models
+
+import "time"
+
+// Role is the role of a user
+type Role string
+
+// Category is a node of the category tree
+type Category struct {
+	Name     string     ` + "`json:\"name\"`" + `
+	Parent   *Category  ` + "`json:\"parent\"`" + `
+	Children []Category ` + "`json:\"children,omitempty\"`" + `
+}
+
+// UserResponse is returned by the user endpoints
+type UserResponse struct {
+	ID        int64          ` + "`json:\"id\" validate:\"min=100\"`" + `
+	Age       int            ` + "`json:\"age\" validate:\"gt=17,lte=130\"`" + `
+	Score     float64        ` + "`json:\"score\" validate:\"lt=1\"`" + `
+	Email     string         ` + "`json:\"email\" validate:\"required,email\"`" + `
+	Code      string         ` + "`json:\"code\" validate:\"len=6,numeric\"`" + `
+	Username  string         ` + "`json:\"username\" binding:\"min=10,alphanum\"`" + `
+	Status    string         ` + "`json:\"status\" validate:\"oneof=active disabled\"`" + `
+	Nickname  *string        ` + "`json:\"nickname,omitempty\"`" + `
+	Tags      []string       ` + "`json:\"tags\" validate:\"min=2,dive,max=3\"`" + `
+	Counts    map[string]int ` + "`json:\"counts\"`" + `
+	Role      Role           ` + "`json:\"role\"`" + `
+	Category  *Category      ` + "`json:\"category\"`" + `
+	CreatedAt time.Time      ` + "`json:\"created_at\"`" + `
+}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	model, err := CollectModel([]string{tempDir}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := GenerateMocks(model, &buf, "./types"); err != nil {
+		t.Fatalf("GenerateMocks failed: %v", err)
+	}
+	content := buf.String()
+
+	for _, expected := range []string{
+		`import type { Category, Role, UserResponse } from "./types";`,
+		"export const MOCK_MAX_DEPTH = 3;",
+		// Aliases return the given value or a sample of the aliased type
+		`export function makeRole(value?: Role, _depth = 0): Role {
+  return value !== undefined ? value : "Role";
+}`,
+		// Recursive references stop at the maximum depth
+		`export function makeCategory(overrides: Partial<Category> = {}, depth = 0): Category {
+  return {
+    name: "name",
+    parent: depth < MOCK_MAX_DEPTH ? makeCategory(undefined, depth + 1) : null,
+    children: depth < MOCK_MAX_DEPTH ? [makeCategory(undefined, depth + 1)] : [],
+    ...overrides,
+  };
+}`,
+		// Sample values respect the validate and binding rules
+		`export function makeUserResponse(overrides: Partial<UserResponse> = {}, depth = 0): UserResponse {
+  return {
+    id: 100,
+    age: 18,
+    score: 0.5,
+    email: "user@example.com",
+    code: "100000",
+    username: "usernamexx",
+    status: "active",
+    nickname: "nickname",
+    tags: ["tag", "tag"],
+    counts: { "key": 1 },
+    role: makeRole(undefined, depth + 1),
+    category: depth < MOCK_MAX_DEPTH ? makeCategory(undefined, depth + 1) : null,
+    created_at: "2024-01-01T00:00:00Z",
+    ...overrides,
+  };
+}`,
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected mocks to contain %q, got:\n%s", expected, content)
+		}
+	}
+
+	// Dates are created as Date objects with the Date mode
+	opts := DefaultOptions()
+	opts.TimeAsDate = true
+	model, err = CollectModel([]string{tempDir}, opts)
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+	buf.Reset()
+	if err := GenerateMocks(model, &buf, "./types"); err != nil {
+		t.Fatalf("GenerateMocks failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `created_at: new Date("2024-01-01T00:00:00Z"),`) {
+		t.Errorf("Expected a Date sample value, got:\n%s", buf.String())
+	}
+}
+
+// TestGenerateMocksRecursiveUnion tests that the factories of self-referencing unions stop at
+// the maximum depth with a member that does not lead back to the union
+func TestGenerateMocksRecursiveUnion(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-mocks-union-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with an expression tree and a union without a terminating member
+	goFilePath := filepath.Join(tempDir, "expr.go")
+	goFileContent := `package expr
+
+// Expr is a node of an expression tree
+//ts:union Binary,Literal
+type Expr interface {
+	Eval() float64
+}
+
+// Binary applies an operator to two expressions
+type Binary struct {
+	Op    string ` + "`json:\"op\"`" + `
+	Left  Expr   ` + "`json:\"left\"`" + `
+	Right Expr   ` + "`json:\"right\"`" + `
+}
+
+// Literal is a constant
+type Literal struct {
+	Value float64 ` + "`json:\"value\"`" + `
+}
+
+// Loop only contains itself
+//ts:union Wrapper
+type Loop interface {
+	Loop()
+}
+
+// Wrapper wraps a loop
+type Wrapper struct {
+	Inner Loop ` + "`json:\"inner\"`" + `
+}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	model, err := CollectModel([]string{tempDir}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := GenerateMocks(model, &buf, "./types"); err != nil {
+		t.Fatalf("GenerateMocks failed: %v", err)
+	}
+	content := buf.String()
+
+	for _, expected := range []string{
+		// Past the limit the union uses Literal, which does not lead back to it
+		`export function makeExpr(value?: Expr, depth = 0): Expr {
+  return value !== undefined ? value : depth < MOCK_MAX_DEPTH ? makeBinary(undefined, depth + 1) : makeLiteral(undefined, depth + 1);
+}`,
+		"    left: makeExpr(undefined, depth + 1),",
+		// Types that only lead back to themselves have no sample value past the limit
+		"    inner: depth < MOCK_MAX_DEPTH ? makeLoop(undefined, depth + 1) : null as never,",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected mocks to contain %q, got:\n%s", expected, content)
+		}
+	}
+}
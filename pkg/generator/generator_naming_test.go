@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestNamingStrategies tests the conversion of names with each naming strategy
+func TestNamingStrategies(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy NamingStrategy
+		expected []string
+	}{
+		{"preserve", NamingPreserve, []string{"UserID", "HTTPServer", "created_at", "Name"}},
+		{"camelCase", NamingCamelCase, []string{"userId", "httpServer", "createdAt", "name"}},
+		{"snake_case", NamingSnakeCase, []string{"user_id", "http_server", "created_at", "name"}},
+		{"PascalCase", NamingPascalCase, []string{"UserId", "HttpServer", "CreatedAt", "Name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, err := ParseNamingStrategy(tt.name)
+			if err != nil {
+				t.Fatalf("ParseNamingStrategy failed: %v", err)
+			}
+			if strategy != tt.strategy {
+				t.Errorf("Expected strategy %q, got %q", tt.strategy, strategy)
+			}
+			for i, input := range []string{"UserID", "HTTPServer", "created_at", "Name"} {
+				if got := strategy.Apply(input); got != tt.expected[i] {
+					t.Errorf("Expected %s to become %q, got %q", input, tt.expected[i], got)
+				}
+			}
+		})
+	}
+
+	if _, err := ParseNamingStrategy("kebab-case"); err == nil {
+		t.Error("Expected an error for an unknown naming strategy")
+	}
+}
+
+// TestPropertyNaming tests the naming of untagged and tagged fields in the generated types
+func TestPropertyNaming(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-naming-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with untagged fields and tag names that are not identifiers
+	goFilePath := filepath.Join(tempDir, "models.go")
+	goFileContent := `package models
+
+// Request is an HTTP request
+type Request struct {
+	RequestID   string
+	ContentType string ` + "`json:\"content-type\"`" + `
+	CreatedAt   string ` + "`json:\"created_at\"`" + `
+}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		opts     func(opts *Options)
+		expected []string
+	}{
+		{
+			name: "default",
+			opts: func(opts *Options) {},
+			// Names that are not identifiers are quoted
+			expected: []string{"  RequestID: string;", `  "content-type": string;`, "  created_at: string;"},
+		},
+		{
+			name:     "untagged fields",
+			opts:     func(opts *Options) { opts.PropertyNaming = NamingCamelCase },
+			expected: []string{"  requestId: string;", `  "content-type": string;`, "  created_at: string;"},
+		},
+		{
+			name: "all fields",
+			opts: func(opts *Options) {
+				opts.PropertyNaming = NamingCamelCase
+				opts.PropertyNamingAll = true
+			},
+			expected: []string{"  requestId: string;", "  contentType: string;", "  createdAt: string;"},
+		},
+		{
+			name: "custom function",
+			opts: func(opts *Options) {
+				opts.PropertyNamingFunc = func(name string) string { return "x_" + strings.ToLower(name) }
+			},
+			expected: []string{"  x_requestid: string;", `  "content-type": string;`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			tt.opts(&opts)
+			model, err := CollectModel([]string{tempDir}, opts)
+			if err != nil {
+				t.Fatalf("CollectModel failed: %v", err)
+			}
+			var buf bytes.Buffer
+			if err := writeTypeScript(&buf, model.Types, model.Types, nil, opts); err != nil {
+				t.Fatalf("writeTypeScript failed: %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(buf.String(), expected) {
+					t.Errorf("Expected TypeScript to contain %q, got:\n%s", expected, buf.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestGenerateOpenAPI tests the OpenAPI document generated from swag annotations
+func TestGenerateOpenAPI(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-openapi-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with general API information, handlers and models
+	goFilePath := filepath.Join(tempDir, "api.go")
+	goFileContent := `package api
+
+// @title Users API
+// @version 2.0
+// @description Manage users
+// @BasePath /api/v1
+
+// User represents a user
+type User struct {
+	ID   int64  ` + "`json:\"id\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// CreateUserRequest is the body of a create request
+type CreateUserRequest struct {
+	Name string ` + "`json:\"name\" binding:\"required\"`" + `
+}
+
+// ListParams are the query parameters of a list request
+type ListParams struct {
+	Page  int    ` + "`form:\"page,omitempty\"`" + `
+	Query string ` + "`form:\"q\"`" + `
+}
+
+// ListUsers godoc
+// @Summary List users
+// @Tags users,admin
+// @Param params query ListParams false "Filters"
+// @Param X-Request-ID header string false "Request ID"
+// @Success 200 {array} User "The users"
+// @Router /users [get]
+func ListUsers() {}
+
+// CreateUser godoc
+// @Summary Create a user
+// @Description Creates a user
+// @Description and returns it
+// @Param user body CreateUserRequest true "User data"
+// @Success 201 {object} User
+// @Router /users [post]
+func CreateUser() {}
+
+// GetUser godoc
+// @Param id path int true "User ID" minimum(1)
+// @Param fields query []string false "Fields" enums(id,name)
+// @Success 200 {object} User
+// @Success 204 {object} nil
+// @Router /users/{id} [get]
+// @Router /accounts/{id} [get]
+func GetUser() {}
+
+// UploadAvatar godoc
+// @Param avatar formData file true "Avatar image"
+// @Param alt formData string false "Alternative text"
+// @Success 200 {string} string "ok"
+// @Router /users/{id}/avatar [put]
+func UploadAvatar() {}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	model, err := CollectModel([]string{tempDir}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+	if len(model.Operations) != 5 {
+		t.Fatalf("Expected 5 operations, got %d", len(model.Operations))
+	}
+	if model.Operations[0].Handler != "ListUsers" {
+		t.Errorf("Expected handler ListUsers, got %q", model.Operations[0].Handler)
+	}
+
+	var jsonBuf bytes.Buffer
+	if err := GenerateOpenAPIJSON(model, &jsonBuf); err != nil {
+		t.Fatalf("GenerateOpenAPIJSON failed: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(jsonBuf.Bytes(), &doc); err != nil {
+		t.Fatalf("Generated document is not valid JSON: %v\n%s", err, jsonBuf.String())
+	}
+
+	paths := doc["paths"].(map[string]any)
+	operation := func(path, method string) any {
+		item, ok := paths[path].(map[string]any)
+		if !ok {
+			t.Fatalf("Missing path %s in:\n%s", path, jsonBuf.String())
+		}
+		return item[method]
+	}
+	get := func(value any, keys ...string) any {
+		for _, key := range keys {
+			value = value.(map[string]any)[key]
+		}
+		return value
+	}
+
+	tests := []struct {
+		name     string
+		actual   any
+		expected string
+	}{
+		{"openapi", doc["openapi"], `"3.1.0"`},
+		{"info", doc["info"], `{"title":"Users API","version":"2.0","description":"Manage users"}`},
+		{"servers", doc["servers"], `[{"url":"/api/v1"}]`},
+		{"tags", get(operation("/users", "get"), "tags"), `["users","admin"]`},
+		{"expanded query", get(operation("/users", "get"), "parameters"), `[
+			{"name":"page","in":"query","schema":{"type":"integer","format":"int64"}},
+			{"name":"q","in":"query","required":true,"schema":{"type":"string"}},
+			{"name":"X-Request-ID","in":"header","description":"Request ID","schema":{"type":"string"}}
+		]`},
+		{"array response", get(operation("/users", "get"), "responses", "200"), `{"description":"The users","content":{"application/json":{"schema":{"type":"array","items":{"$ref":"#/components/schemas/User"}}}}}`},
+		{"description", get(operation("/users", "post"), "description"), `"Creates a user\nand returns it"`},
+		{"request body", get(operation("/users", "post"), "requestBody"), `{"description":"User data","required":true,"content":{"application/json":{"schema":{"$ref":"#/components/schemas/CreateUserRequest"}}}}`},
+		{"status text", get(operation("/users", "post"), "responses", "201", "description"), `"Created"`},
+		{"path parameter", get(operation("/users/{id}", "get"), "parameters"), `[
+			{"name":"id","in":"path","description":"User ID","required":true,"schema":{"type":"integer","format":"int64","minimum":1}},
+			{"name":"fields","in":"query","description":"Fields","schema":{"type":"array","items":{"type":"string"},"enum":["id","name"]}}
+		]`},
+		{"no content", get(operation("/users/{id}", "get"), "responses", "204"), `{"description":"No Content"}`},
+		{"multiple routers", get(operation("/accounts/{id}", "get"), "responses", "204"), `{"description":"No Content"}`},
+		{"undeclared path parameter", get(operation("/users/{id}/avatar", "put"), "parameters"), `[{"name":"id","in":"path","required":true,"schema":{"type":"string"}}]`},
+		{"form data", get(operation("/users/{id}/avatar", "put"), "requestBody"), `{"content":{"multipart/form-data":{"schema":{
+			"type":"object",
+			"properties":{"avatar":{"description":"Avatar image","type":"string","format":"binary"},"alt":{"description":"Alternative text","type":"string"}},
+			"required":["avatar"]
+		}}}}`},
+		{"string response", get(operation("/users/{id}/avatar", "put"), "responses", "200"), `{"description":"ok","content":{"application/json":{"schema":{"type":"string"}}}}`},
+		{"component", get(doc, "components", "schemas", "User"), `{"description":"User represents a user","type":"object","properties":{"id":{"type":"integer","format":"int64"},"name":{"type":"string"}},"required":["id","name"]}`},
+	}
+
+	for _, tt := range tests {
+		var expected any
+		if err := json.Unmarshal([]byte(tt.expected), &expected); err != nil {
+			t.Fatalf("%s: invalid expected JSON: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(tt.actual, expected) {
+			actual, _ := json.Marshal(tt.actual)
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.expected, actual)
+		}
+	}
+
+	// The YAML document describes the same structure
+	var yamlBuf bytes.Buffer
+	if err := GenerateOpenAPIYAML(model, &yamlBuf); err != nil {
+		t.Fatalf("GenerateOpenAPIYAML failed: %v", err)
+	}
+	for _, expected := range []string{
+		"openapi: \"3.1.0\"\n",
+		"  /users/{id}:\n    get:\n",
+		"        \"200\":\n",
+		"              $ref: \"#/components/schemas/CreateUserRequest\"\n",
+		"      tags:\n        - users\n        - admin\n",
+	} {
+		if !strings.Contains(yamlBuf.String(), expected) {
+			t.Errorf("Expected YAML to contain %q, got:\n%s", expected, yamlBuf.String())
+		}
+	}
+}
+
+// TestEncodeYAML tests the quoting of YAML scalars and the layout of collections
+func TestEncodeYAML(t *testing.T) {
+	doc := newJSONObject().
+		Set("plain", "Get all users").
+		Set("number", "200").
+		Set("bool", "true").
+		Set("ref", "#/components/schemas/User").
+		Set("colon", "a: b").
+		Set("empty", "").
+		Set("multiline", "a\nb").
+		Set("int", 3).
+		Set("list", []any{"a", newJSONObject().Set("x", 1).Set("y", []string{"b"})}).
+		Set("emptyObject", newJSONObject()).
+		Set("emptyList", []string{})
+
+	expected := `plain: Get all users
+number: "200"
+bool: "true"
+ref: "#/components/schemas/User"
+colon: "a: b"
+empty: ""
+multiline: "a\nb"
+int: 3
+list:
+  - a
+  - x: 1
+    "y":
+      - b
+emptyObject: {}
+emptyList: []
+`
+	if actual := string(encodeYAML(doc)); actual != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, actual)
+	}
+}
@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestReadonly tests the readonly properties and collections of each readonly mode
+func TestReadonly(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-readonly-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with request, response and nested types
+	goFilePath := filepath.Join(tempDir, "api.go")
+	goFileContent := `package api
+
+// Address is nested in the response
+type Address struct {
+	Lines []string ` + "`json:\"lines\"`" + `
+}
+
+// CreateUserRequest is the payload to create a user
+type CreateUserRequest struct {
+	Name  string   ` + "`json:\"name\"`" + `
+	Roles []string ` + "`json:\"roles\"`" + `
+}
+
+// UserResponse is returned by the user endpoints
+type UserResponse struct {
+	ID      int64             ` + "`json:\"id\" ts:\"readonly\"`" + `
+	Address Address           ` + "`json:\"address\"`" + `
+	Labels  map[string]string ` + "`json:\"labels\"`" + `
+}
+
+// CreateUser godoc
+// @Param user body CreateUserRequest true "User"
+// @Success 201 {object} UserResponse
+// @Router /users [post]
+func CreateUser() {}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	tests := []struct {
+		mode       ReadonlyMode
+		expected   []string
+		unexpected []string
+	}{
+		{
+			mode:       ReadonlyNone,
+			expected:   []string{"  name: string;", "  readonly id: number;", "  labels: Record<string, string>;"},
+			unexpected: []string{"ReadonlyArray"},
+		},
+		{
+			mode: ReadonlyResponses,
+			expected: []string{
+				// Request types stay mutable
+				"  name: string;",
+				"  roles: string[];",
+				"  readonly id: number;",
+				"  readonly address: Address;",
+				"  readonly labels: Readonly<Record<string, string>>;",
+				// Types nested in responses are readonly too
+				"  readonly lines: ReadonlyArray<string>;",
+			},
+		},
+		{
+			mode:     ReadonlyAll,
+			expected: []string{"  readonly name: string;", "  readonly roles: ReadonlyArray<string>;", "  readonly lines: ReadonlyArray<string>;"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.Readonly = tt.mode
+			model, err := CollectModel([]string{tempDir}, opts)
+			if err != nil {
+				t.Fatalf("CollectModel failed: %v", err)
+			}
+			var buf bytes.Buffer
+			if err := writeTypeScript(&buf, model.Types, model.Types, nil, opts); err != nil {
+				t.Fatalf("writeTypeScript failed: %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(buf.String(), expected) {
+					t.Errorf("Expected TypeScript to contain %q, got:\n%s", expected, buf.String())
+				}
+			}
+			for _, unexpected := range tt.unexpected {
+				if strings.Contains(buf.String(), unexpected) {
+					t.Errorf("Expected TypeScript not to contain %q, got:\n%s", unexpected, buf.String())
+				}
+			}
+		})
+	}
+
+	if _, err := ParseReadonlyMode("deep"); err == nil {
+		t.Error("Expected an error for an unknown readonly mode")
+	}
+}
@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRenderers tests the built-in and registered renderers
+func TestRenderers(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-renderer-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with a request and a response type
+	goFilePath := filepath.Join(tempDir, "api.go")
+	goFileContent := `package api
+
+// User is returned by the user endpoints
+type User struct {
+	ID   int64  ` + "`json:\"id\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// GetUser godoc
+// @Success 200 {object} User
+// @Router /users/{id} [get]
+func GetUser() {}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	model, err := CollectModel([]string{tempDir}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+
+	// The TypeScript renderer is the default output
+	var buf bytes.Buffer
+	if err := (TypeScriptRenderer{Options: DefaultOptions()}).Render(model, &buf); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "export interface User {") {
+		t.Errorf("Expected the TypeScript renderer to write the User interface, got:\n%s", buf.String())
+	}
+
+	// Modules importing the types are rendered relative to the types file
+	target := RenderTarget{
+		Path:      filepath.Join(tempDir, "out", "guards.ts"),
+		TypesFile: filepath.Join(tempDir, "types.ts"),
+		Options:   DefaultOptions(),
+	}
+	guards, err := LookupRenderer("guards", target)
+	if err != nil {
+		t.Fatalf("LookupRenderer failed: %v", err)
+	}
+	buf.Reset()
+	if err := guards.Render(model, &buf); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `import type { User } from "../types";`) {
+		t.Errorf("Expected the guards to import the types from ../types, got:\n%s", buf.String())
+	}
+
+	// Registered renderers are looked up by name and written to files
+	RegisterRenderer("test-names", func(target RenderTarget) Renderer {
+		return RendererFunc(func(model *Model, w io.Writer) error {
+			for _, typ := range model.Types {
+				if _, err := fmt.Fprintln(w, typ.Name); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+	if !containsString(RendererNames(), "test-names") || !containsString(RendererNames(), "ts") {
+		t.Errorf("Expected the registered and built-in renderers, got %v", RendererNames())
+	}
+	renderer, err := LookupRenderer("test-names", RenderTarget{})
+	if err != nil {
+		t.Fatalf("LookupRenderer failed: %v", err)
+	}
+	namesFile := filepath.Join(tempDir, "names.txt")
+	if err := RenderToFile(renderer, model, namesFile); err != nil {
+		t.Fatalf("RenderToFile failed: %v", err)
+	}
+	content, err := os.ReadFile(namesFile)
+	if err != nil {
+		t.Fatalf("Failed to read the rendered file: %v", err)
+	}
+	if string(content) != "User\n" {
+		t.Errorf("Expected the type names, got %q", content)
+	}
+
+	if _, err := LookupRenderer("dart", RenderTarget{}); err == nil {
+		t.Error("Expected an error for an unknown renderer")
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected registering a renderer twice to panic")
+			}
+		}()
+		RegisterRenderer("ts", func(RenderTarget) Renderer { return TypeScriptRenderer{} })
+	}()
+}
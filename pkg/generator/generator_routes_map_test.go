@@ -0,0 +1,149 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRouteMap tests the typed route map generated from the operations
+func TestRouteMap(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-route-map-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with annotated handlers
+	goFilePath := filepath.Join(tempDir, "api.go")
+	goFileContent := `package api
+
+// User represents a user
+type User struct {
+	ID int64 ` + "`json:\"id\"`" + `
+}
+
+// CreateUserRequest is the payload to create a user
+type CreateUserRequest struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// ListUsersQuery filters the users
+type ListUsersQuery struct {
+	Name string ` + "`json:\"name,omitempty\"`" + `
+}
+
+// ErrorResponse is returned on errors
+type ErrorResponse struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+
+// GetPost godoc
+// @Summary Get a post of a user
+// @Param id path integer true "User ID"
+// @Param include query string false "Relations"
+// @Success 200 {object} Post
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id}/posts/{postId} [get]
+func GetPost() {}
+
+// ListUsers godoc
+// @Param query query ListUsersQuery false "Filters"
+// @Success 200 {array} User
+// @Router /users [get]
+func ListUsers() {}
+
+// CreateUser godoc
+// @Param user body CreateUserRequest true "User"
+// @Success 201 {object} User
+// @Router /users [post]
+func CreateUser() {}
+
+// DeleteUser godoc
+// @Deprecated
+// @Success 204
+// @Router /users/:id [delete]
+func DeleteUser() {}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	model, err := CollectModel([]string{tempDir}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+
+	routesFilePath := filepath.Join(tempDir, "generated", "types.routes.ts")
+	if err := os.MkdirAll(filepath.Dir(routesFilePath), 0755); err != nil {
+		t.Fatalf("Failed to create output directory: %v", err)
+	}
+	if err := WriteRoutes(model, routesFilePath, filepath.Join(tempDir, "generated", "types.ts"), DefaultOptions()); err != nil {
+		t.Fatalf("WriteRoutes failed: %v", err)
+	}
+	content, err := os.ReadFile(routesFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read generated route map: %v", err)
+	}
+	contentStr := string(content)
+
+	for _, expected := range []string{
+		// Only the used collected types are imported, unknown types get placeholders
+		"import type { CreateUserRequest, ListUsersQuery, User } from \"./types\";",
+		"type Post = any;",
+		// Path parameters are typed from @Param and default to string
+		`  /** Get a post of a user */
+  "GET /users/{id}/posts/{postId}": {
+    params: { id: number; postId: string };
+    query: { include?: string };
+    body: undefined;
+    response: Post;
+  };`,
+		`  "GET /users": {
+    params: Record<string, never>;
+    query: ListUsersQuery;
+    body: undefined;
+    response: User[];
+  };`,
+		`  "POST /users": {
+    params: Record<string, never>;
+    query: Record<string, never>;
+    body: CreateUserRequest;
+    response: User;
+  };`,
+		`  /** @deprecated */
+  "DELETE /users/{id}": {
+    params: { id: string };
+    query: Record<string, never>;
+    body: undefined;
+    response: void;
+  };`,
+		"export type PathParams<P extends string> = { [K in PathParamNames<P>]: string | number };",
+		"export function buildPath<P extends string>(path: P, params: PathParams<P>): string {",
+		"export type RouteResponse<K extends RouteKey> = Routes[K][\"response\"];",
+	} {
+		if !strings.Contains(contentStr, expected) {
+			t.Errorf("Expected route map to contain %q, got:\n%s", expected, contentStr)
+		}
+	}
+	if strings.Contains(contentStr, "ErrorResponse") {
+		t.Errorf("Failure responses should not be part of the route map:\n%s", contentStr)
+	}
+
+	// API types are imported from the API types file when it is set
+	opts := DefaultOptions()
+	opts.APITypesFile = filepath.Join(tempDir, "generated", "api.ts")
+	if err := WriteRoutes(model, routesFilePath, filepath.Join(tempDir, "types.ts"), opts); err != nil {
+		t.Fatalf("WriteRoutes failed: %v", err)
+	}
+	content, err = os.ReadFile(routesFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read generated route map: %v", err)
+	}
+	if !strings.Contains(string(content), "import type { CreateUserRequest, ListUsersQuery, User } from \"./api\";") {
+		t.Errorf("Expected API types to be imported from ./api, got:\n%s", content)
+	}
+}
@@ -0,0 +1,314 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestDiscoverRoutes tests the discovery of routes from gin, echo, chi and net/http router setup code
+func TestDiscoverRoutes(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-routes-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create test Go files with router setup code and handlers in separate files
+	files := map[string]string{
+		"routes.go": `package api
+
+const apiPrefix = "/api"
+
+func setupGin(h *UserHandler) {
+	r := gin.Default()
+	api := r.Group(apiPrefix)
+	{
+		v1 := api.Group("/v1")
+		v1.GET("/users/:id", auth, h.GetUser)
+		v1.POST("/users", h.CreateUser)
+		v1.GET("/files/*path", func(c *gin.Context) {
+			c.JSON(http.StatusOK, []File{})
+		})
+	}
+	r.GET("/documented", h.Documented)
+}
+
+func setupEcho(e *echo.Echo) {
+	admin := e.Group("/admin")
+	admin.DELETE("/users/:id", deleteUser)
+	e.Add("PATCH", "/settings", updateSettings())
+}
+
+func setupChi() {
+	r := chi.NewRouter()
+	r.Route("/orders", func(r chi.Router) {
+		r.With(auth).Get("/{id:[0-9]+}", getOrder)
+		r.Group(func(r chi.Router) {
+			r.Post("/", createOrder)
+		})
+	})
+	r.Get("/health", health)
+}
+
+func setupMux() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /items/{id}", getItem)
+	mux.Handle("POST /items", http.HandlerFunc(createItem))
+	mux.HandleFunc("/legacy", legacy)
+}
+`,
+		"handlers.go": `package api
+
+// User represents a user
+type User struct {
+	ID int64 ` + "`json:\"id\"`" + `
+}
+
+// CreateUserRequest is the payload to create a user
+type CreateUserRequest struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// File is a stored file
+type File struct {
+	Path string ` + "`json:\"path\"`" + `
+}
+
+// Order is an order
+type Order struct {
+	ID int64 ` + "`json:\"id\"`" + `
+}
+
+// Item is an item
+type Item struct {
+	ID int64 ` + "`json:\"id\"`" + `
+}
+
+// Settings are the user settings
+type Settings struct {
+	Theme string ` + "`json:\"theme\"`" + `
+}
+
+// Documented is described with swag annotations
+type Documented struct {
+	OK bool ` + "`json:\"ok\"`" + `
+}
+
+type UserHandler struct{}
+
+// GetUser returns a user
+func (h *UserHandler) GetUser(c *gin.Context) {
+	var user User
+	c.JSON(http.StatusOK, user)
+}
+
+// CreateUser creates a user
+func (h *UserHandler) CreateUser(c *gin.Context) {
+	var req CreateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return
+	}
+	c.JSON(http.StatusCreated, User{})
+}
+
+// Documented godoc
+// @Summary Annotated
+// @Success 200 {object} Documented
+// @Router /documented [get]
+func (h *UserHandler) Documented(c *gin.Context) {}
+
+func deleteUser(c echo.Context) error {
+	return c.NoContent(http.StatusNoContent)
+}
+
+func updateSettings() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var settings Settings
+		if err := c.Bind(&settings); err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, settings)
+	}
+}
+
+func getOrder(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(Order{})
+}
+
+func createOrder(w http.ResponseWriter, r *http.Request) {
+	var order Order
+	json.NewDecoder(r.Body).Decode(&order)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(order)
+}
+
+func health(w http.ResponseWriter, r *http.Request) {}
+
+func getItem(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode([]Item{})
+}
+
+func createItem(w http.ResponseWriter, r *http.Request) {
+	var item Item
+	json.NewDecoder(r.Body).Decode(&item)
+}
+
+func legacy(w http.ResponseWriter, r *http.Request) {}
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test Go file: %v", err)
+		}
+	}
+
+	operations, err := DiscoverRoutes([]string{tempDir})
+	if err != nil {
+		t.Fatalf("DiscoverRoutes failed: %v", err)
+	}
+
+	// Each route is summarised as "method path handler request -> status response, ..."
+	describe := func(op Operation) string {
+		summary := op.Method + " " + op.Path + " " + op.Handler
+		for _, param := range op.Parameters {
+			summary += " " + param.In + ":" + param.Type.String()
+		}
+		var responses []string
+		for _, resp := range op.Responses {
+			responses = append(responses, resp.Status+" "+resp.Type.String())
+		}
+		return summary + " -> " + strings.Join(responses, ", ")
+	}
+	var actual []string
+	for _, op := range operations {
+		actual = append(actual, describe(op))
+	}
+	expected := []string{
+		"get /api/v1/users/{id} GetUser -> 200 User",
+		"post /api/v1/users CreateUser body:CreateUserRequest -> 201 User",
+		"get /api/v1/files/{path}  -> 200 File[]",
+		"get /documented Documented -> ",
+		"delete /admin/users/{id} deleteUser -> ",
+		"patch /settings updateSettings body:Settings -> 200 Settings",
+		"get /orders/{id} getOrder -> 200 Order",
+		"post /orders/ createOrder body:Order -> 201 Order",
+		"get /health health -> ",
+		"get /items/{id} getItem -> 200 Item[]",
+		"post /items createItem body:Item -> ",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected routes:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+	if operations[0].Summary != "GetUser returns a user" {
+		t.Errorf("Expected the handler doc comment as summary, got %q", operations[0].Summary)
+	}
+
+	// Discovered routes feed the endpoint information, without replacing annotated routes
+	opts := DefaultOptions()
+	opts.DiscoverRoutes = true
+	model, err := CollectModel([]string{tempDir}, opts)
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+	if len(model.Operations) != len(expected) {
+		t.Errorf("Expected %d operations, got %d", len(expected), len(model.Operations))
+	}
+	if model.Operations[0].Summary != "Annotated" {
+		t.Errorf("Expected the annotated operation first, got %+v", model.Operations[0])
+	}
+
+	endpoints := make(map[string][]EndpointInfo)
+	for _, typ := range model.Types {
+		endpoints[typ.Name] = typ.Endpoints
+	}
+	expectedRequest := EndpointInfo{Method: "post", Path: "/api/v1/users", Request: true, Summary: "CreateUser creates a user"}
+	if !reflect.DeepEqual(endpoints["CreateUserRequest"], []EndpointInfo{expectedRequest}) {
+		t.Errorf("Expected endpoint %+v, got %+v", expectedRequest, endpoints["CreateUserRequest"])
+	}
+	expectedResponse := EndpointInfo{Method: "get", Path: "/items/{id}", Response: true, Status: "200"}
+	if !reflect.DeepEqual(endpoints["Item"][0], expectedResponse) {
+		t.Errorf("Expected endpoint %+v, got %+v", expectedResponse, endpoints["Item"])
+	}
+
+	// Without the option only the annotated route is collected
+	model, err = CollectModel([]string{tempDir}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+	if len(model.Operations) != 1 {
+		t.Errorf("Expected 1 operation without route discovery, got %d", len(model.Operations))
+	}
+}
+
+// TestDiscoverRoutesThroughFunctions tests group prefixes passed to the functions registering
+// routes on a router parameter
+func TestDiscoverRoutesThroughFunctions(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-routes-funcs-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	goFileContent := `package api
+
+func setupRouter() {
+	r := gin.Default()
+	api := r.Group("/api")
+	registerUsers(api)
+	registerAdmin(1, api.Group("/admin"))
+	routes.RegisterHealth(r)
+}
+
+func registerUsers(rg *gin.RouterGroup) {
+	rg.GET("/users", listUsers)
+	registerProfiles(rg.Group("/users/:id"))
+}
+
+func registerProfiles(rg *gin.RouterGroup) {
+	rg.GET("/profile", getProfile)
+}
+
+func registerAdmin(version int, rg *gin.RouterGroup) {
+	rg.DELETE("/cache", clearCache)
+}
+
+func RegisterHealth(r *gin.Engine) {
+	r.GET("/health", health)
+}
+
+// loop calls itself with a group of its router, which is not followed
+func loop(rg *gin.RouterGroup) {
+	rg.GET("/loop", health)
+	loop(rg.Group("/again"))
+}
+`
+	if err := os.WriteFile(filepath.Join(tempDir, "routes.go"), []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	operations, err := DiscoverRoutes([]string{tempDir})
+	if err != nil {
+		t.Fatalf("DiscoverRoutes failed: %v", err)
+	}
+	var actual []string
+	for _, op := range operations {
+		actual = append(actual, op.Method+" "+op.Path)
+	}
+	// Functions called with a router are registered under the prefix of each caller; a
+	// router that is not a known group, like gin.Default(), keeps the function routes as-is
+	expected := []string{
+		"get /api/users",
+		"get /api/users/{id}/profile",
+		"delete /api/admin/cache",
+		"get /health",
+		"get /loop",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected routes:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerateStdlibTypes tests the mapping of standard library types to their JSON representation
+func TestGenerateStdlibTypes(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-stdlib-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with standard library types
+	goFilePath := filepath.Join(tempDir, "stdlib_models.go")
+	goFileContent := `package stdlib
+
+import (
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Server uses standard library types with special JSON encodings
+type Server struct {
+	ID        uuid.UUID      ` + "`json:\"id\"`" + `
+	Timeout   time.Duration  ` + "`json:\"timeout\"`" + `
+	Address   net.IP         ` + "`json:\"address\"`" + `
+	Balance   big.Int        ` + "`json:\"balance\"`" + `
+	Amount    json.Number    ` + "`json:\"amount\"`" + `
+	Payload   []byte         ` + "`json:\"payload\"`" + `
+	Intervals []time.Duration ` + "`json:\"intervals\"`" + `
+	StartedAt time.Time      ` + "`json:\"started_at\"`" + `
+	Endpoint  url.URL        ` + "`json:\"endpoint\"`" + `
+}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	// Generate TypeScript types with an additional mapping
+	tsFilePath := filepath.Join(tempDir, "generated.ts")
+	opts := DefaultOptions()
+	opts.TypeMappings = map[string]TypeMapping{
+		"github.com/google/uuid.UUID": {TSType: "string", Format: "uuid", Hint: "UUID"},
+	}
+	if err := GenerateTypesWithOptions([]string{tempDir}, tsFilePath, opts); err != nil {
+		t.Fatalf("GenerateTypesWithOptions failed: %v", err)
+	}
+
+	// Read the generated TypeScript file
+	tsContent, err := os.ReadFile(tsFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read generated TypeScript file: %v", err)
+	}
+
+	// Check for expected content
+	tsContentStr := string(tsContent)
+
+	for _, expected := range []string{
+		"id: string /* UUID */;",
+		"timeout: number /* nanoseconds */;",
+		"address: string /* IP address */;",
+		"balance: number /* arbitrary precision integer */;",
+		"amount: number | string /* json.Number */;",
+		"payload: string /* base64 */;",
+		"intervals: number[] /* nanoseconds */;",
+		"started_at: string /* RFC3339 */;",
+		// url.URL is a struct written as a JSON object of its fields, declared under a name
+		// that does not shadow the URL global
+		"endpoint: NetURL;",
+		"export interface NetURL {\n  Scheme: string;",
+		"  ForceQuery: boolean;",
+	} {
+		if !strings.Contains(tsContentStr, expected) {
+			t.Errorf("Generated TypeScript does not contain %q", expected)
+			t.Logf("Generated TypeScript:\n%s", tsContentStr)
+		}
+	}
+
+	// Mapped types must not produce placeholders
+	if strings.Contains(tsContentStr, "Placeholders for undefined types") {
+		t.Errorf("Generated TypeScript contains placeholders for mapped types:\n%s", tsContentStr)
+	}
+	if strings.Contains(tsContentStr, "type URL") {
+		t.Errorf("Generated TypeScript declares a URL type shadowing the global:\n%s", tsContentStr)
+	}
+}
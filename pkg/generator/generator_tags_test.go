@@ -0,0 +1,67 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerateTagParsing tests that struct tags are parsed with reflect.StructTag semantics
+func TestGenerateTagParsing(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-tags-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with unusual but valid tags
+	goFilePath := filepath.Join(tempDir, "tag_models.go")
+	goFileContent := `package tags
+
+// TaggedStruct uses tags with extra spaces and quoted values containing spaces
+type TaggedStruct struct {
+	Name     string ` + "`json:\"name\"  form:\"x\"`" + `
+	Sort     string ` + "`json:\"sort\" validate:\"oneof=asc desc\"`" + `
+	Kind     string ` + "`validate:\"oneof=a b c\" json:\"kind,omitempty\"`" + `
+	Escaped  string ` + "`json:\"escaped\" validate:\"contains=\\\\\\\"\"`" + `
+	Literal  string "json:\"literal\""
+}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	// Generate TypeScript types
+	tsFilePath := filepath.Join(tempDir, "generated.ts")
+	if err := GenerateTypes(tempDir, tsFilePath); err != nil {
+		t.Fatalf("GenerateTypes failed: %v", err)
+	}
+
+	// Read the generated TypeScript file
+	tsContent, err := os.ReadFile(tsFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read generated TypeScript file: %v", err)
+	}
+
+	// Check for expected content
+	tsContentStr := string(tsContent)
+
+	for _, expected := range []string{
+		"name: string;",
+		"sort: string;",
+		"kind?: string;",
+		"escaped: string;",
+		"literal: string;",
+		"  - validate: oneof=asc desc",
+		"  - validate: oneof=a b c",
+		`  - validate: contains=\"`,
+	} {
+		if !strings.Contains(tsContentStr, expected) {
+			t.Errorf("Generated TypeScript does not contain %q", expected)
+			t.Logf("Generated TypeScript:\n%s", tsContentStr)
+		}
+	}
+}
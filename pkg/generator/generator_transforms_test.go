@@ -0,0 +1,172 @@
+package generator
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestTransformHooks tests the field transforms, type transforms and filters
+func TestTransformHooks(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-transforms-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with money amounts and internal fields
+	goFilePath := filepath.Join(tempDir, "api.go")
+	goFileContent := `package api
+
+// Money is an amount in a currency
+type Money struct {
+	Cents    int64  ` + "`json:\"cents\"`" + `
+	Currency string ` + "`json:\"currency\"`" + `
+}
+
+// Order is an order of a customer
+type Order struct {
+	ID            int64    ` + "`json:\"id\"`" + `
+	TotalCents    int64    ` + "`json:\"total_cents\"`" + `
+	DiscountCents *int64   ` + "`json:\"discount_cents\"`" + `
+	InternalNote  string   ` + "`json:\"internal_note\"`" + `
+	Tags          []string ` + "`json:\"tags\"`" + `
+}
+
+// InternalCache is only used by the server
+type InternalCache struct {
+	Keys []string ` + "`json:\"keys\"`" + `
+}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	var transformedNodes []string
+	targetFile := filepath.Join(tempDir, "types.ts")
+	err = GenerateTypesFromMultipleDirs([]string{tempDir}, targetFile,
+		// Fields named *_cents become Money, keeping their nullability
+		WithFieldTransform(func(node *ast.Field, field *TypeScriptField) {
+			transformedNodes = append(transformedNodes, node.Names[0].Name)
+			if !strings.HasSuffix(field.Name, "_cents") {
+				return
+			}
+			field.Name = strings.TrimSuffix(field.Name, "_cents")
+			if field.TypeRef.Kind == KindNullable {
+				field.TypeRef = &TypeRef{Kind: KindNullable, Elem: namedRef("Money")}
+			} else {
+				field.TypeRef = namedRef("Money")
+			}
+		}),
+		// internal_ fields and Internal types are left out
+		WithFilter(func(node ast.Node, field *TypeScriptField) bool {
+			if field != nil {
+				return !strings.HasPrefix(field.Name, "internal_")
+			}
+			return !strings.HasPrefix(node.(*ast.TypeSpec).Name.Name, "Internal")
+		}),
+		WithTypeTransform(func(node *ast.TypeSpec, t *TypeScriptType) {
+			t.Comment += "Declared in package " + t.Package
+		}),
+	)
+	if err != nil {
+		t.Fatalf("GenerateTypesFromMultipleDirs failed: %v", err)
+	}
+
+	content, err := os.ReadFile(targetFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated TypeScript file: %v", err)
+	}
+	tsContent := string(content)
+
+	for _, expected := range []string{
+		"  total: Money;",
+		"  discount: Money | null;",
+		"  tags: string[];",
+		"  cents: number;",
+		" * Declared in package api",
+	} {
+		if !strings.Contains(tsContent, expected) {
+			t.Errorf("Expected TypeScript to contain %q, got:\n%s", expected, tsContent)
+		}
+	}
+	for _, unexpected := range []string{"internal_note", "InternalCache", "total_cents"} {
+		if strings.Contains(tsContent, unexpected) {
+			t.Errorf("Expected TypeScript not to contain %q, got:\n%s", unexpected, tsContent)
+		}
+	}
+
+	// Filtered fields are not transformed
+	for _, name := range transformedNodes {
+		if name == "InternalNote" || name == "Keys" {
+			t.Errorf("Expected the filtered field %s not to be transformed", name)
+		}
+	}
+}
+
+// TestFieldTransformTypeSync tests that the field type follows the TypeRef or the Type
+// changed by a transform
+func TestFieldTransformTypeSync(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-transforms-sync-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	goFilePath := filepath.Join(tempDir, "api.go")
+	goFileContent := `package api
+
+// Event is an event of a calendar
+type Event struct {
+	ID      string   ` + "`json:\"id\"`" + `
+	Kind    string   ` + "`json:\"kind\"`" + `
+	Guests  []string ` + "`json:\"guests\"`" + `
+	Private bool     ` + "`json:\"private\"`" + `
+}
+`
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	opts := applyOptions([]Option{
+		WithFieldTransform(func(node *ast.Field, field *TypeScriptField) {
+			switch field.Name {
+			case "id":
+				// Only the Type changes
+				field.Type = "EventID"
+			case "kind":
+				field.Type = `"meeting" | "call"`
+			case "guests":
+				// The TypeRef changes in place
+				field.TypeRef.Elem.Name = "Email"
+			}
+		}),
+	})
+	model, err := CollectModel([]string{tempDir}, opts)
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"id":      "EventID",
+		"kind":    `"meeting" | "call"`,
+		"guests":  "Email[]",
+		"private": "boolean",
+	}
+	for _, field := range model.Types[0].Fields {
+		if field.Type != expected[field.Name] {
+			t.Errorf("Expected field %s to have the type %s, got %s", field.Name, expected[field.Name], field.Type)
+		}
+		if field.TypeRef == nil || field.TypeRef.String() != field.Type {
+			t.Errorf("Expected the TypeRef of field %s to match its type %s, got %+v", field.Name, field.Type, field.TypeRef)
+		}
+	}
+	if ref := model.Types[0].Fields[0].TypeRef; ref.Kind != KindPrimitive {
+		t.Errorf("Expected a primitive TypeRef for a changed Type, got %+v", ref)
+	}
+}
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestTypeNaming tests the transformation of the emitted type names
+func TestTypeNaming(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-typenames-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with an endpoint, an unexported type and a placeholder
+	goFilePath := filepath.Join(tempDir, "users.go")
+	goFileContent := `package users
+
+// UserResponse is returned by the user endpoints
+type UserResponse struct {
+	ID      int64        ` + "`json:\"id\"`" + `
+	Address *addressInfo ` + "`json:\"address\"`" + `
+	Avatar  Image        ` + "`json:\"avatar\"`" + `
+}
+
+// addressInfo is the address of a user
+type addressInfo struct {
+	City string ` + "`json:\"city\"`" + `
+}
+
+// GetUser godoc
+// @Success 200 {object} UserResponse
+// @Router /users/{id} [get]
+func GetUser() {}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		naming     TypeNaming
+		expected   []string
+		unexpected []string
+	}{
+		{
+			name:   "prefix and trimmed suffix",
+			naming: TypeNaming{Prefix: "I", TrimSuffixes: []string{"Response"}},
+			expected: []string{
+				"type IImage = any;",
+				"export interface IUser {",
+				"  address: IaddressInfo | null;",
+				"  avatar: IImage;",
+				"export interface IaddressInfo {",
+			},
+		},
+		{
+			name:   "exported unexported types with a suffix",
+			naming: TypeNaming{Suffix: "DTO", Unexported: UnexportedExport},
+			expected: []string{
+				"export interface UserResponseDTO {",
+				"  address: AddressInfoDTO | null;",
+				"export interface AddressInfoDTO {",
+			},
+			unexpected: []string{"This is an unexported type"},
+		},
+		{
+			name:       "skipped unexported types",
+			naming:     TypeNaming{Unexported: UnexportedSkip},
+			expected:   []string{"type addressInfo = any;", "  address: addressInfo | null;"},
+			unexpected: []string{"export interface addressInfo {"},
+		},
+		{
+			name:     "qualified packages",
+			naming:   TypeNaming{QualifyPackages: true},
+			expected: []string{"export interface UsersUserResponse {", "  address: UsersAddressInfo | null;", "type Image = any;"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.TypeNaming = tt.naming
+			model, err := CollectModel([]string{tempDir}, opts)
+			if err != nil {
+				t.Fatalf("CollectModel failed: %v", err)
+			}
+			var buf bytes.Buffer
+			if err := writeTypeScript(&buf, model.Types, model.Types, nil, opts); err != nil {
+				t.Fatalf("writeTypeScript failed: %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(buf.String(), expected) {
+					t.Errorf("Expected TypeScript to contain %q, got:\n%s", expected, buf.String())
+				}
+			}
+			for _, unexpected := range tt.unexpected {
+				if strings.Contains(buf.String(), unexpected) {
+					t.Errorf("Expected TypeScript not to contain %q, got:\n%s", unexpected, buf.String())
+				}
+			}
+		})
+	}
+
+	// References from the operations and the endpoint documentation use the new names
+	opts := DefaultOptions()
+	opts.TypeNaming = TypeNaming{Prefix: "I"}
+	model, err := CollectModel([]string{tempDir}, opts)
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+	if got := model.Operations[0].Responses[0].Type.String(); got != "IUserResponse" {
+		t.Errorf("Expected the response type IUserResponse, got %s", got)
+	}
+	for _, typ := range model.Types {
+		if typ.Name == "IUserResponse" && len(typ.Endpoints) != 1 {
+			t.Errorf("Expected IUserResponse to keep its endpoint, got %v", typ.Endpoints)
+		}
+	}
+
+	// Names that collide after the transformation are rejected
+	opts.TypeNaming = TypeNaming{Func: func(name string) string { return "Same" }}
+	if _, err := CollectModel([]string{tempDir}, opts); err == nil {
+		t.Error("Expected an error for colliding type names")
+	}
+}
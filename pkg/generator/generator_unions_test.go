@@ -0,0 +1,234 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestInterfaceUnions tests the unions generated for Go interfaces
+func TestInterfaceUnions(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-unions-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with a declared union and an interface with implementations
+	goFilePath := filepath.Join(tempDir, "shapes.go")
+	goFileContent := `package shapes
+
+// Shape is a drawable shape
+//ts:union Circle=circle,Square discriminator=kind
+type Shape interface {
+	Area() float64
+}
+
+// Animal makes sounds
+type Animal interface {
+	Named
+	Sound() string
+}
+
+// Named has a name
+type Named interface {
+	Name() string
+}
+
+// Circle is a round shape
+type Circle struct {
+	Kind   string  ` + "`json:\"kind,omitempty\"`" + `
+	Radius float64 ` + "`json:\"radius\"`" + `
+}
+
+func (c Circle) Area() float64 { return 0 }
+
+// Square is a square shape
+type Square struct {
+	Side float64 ` + "`json:\"side\"`" + `
+}
+
+func (s *Square) Area() float64 { return 0 }
+
+// Dog barks
+type Dog struct {
+	Nickname string ` + "`json:\"nickname\"`" + `
+}
+
+func (d Dog) Name() string  { return d.Nickname }
+func (d Dog) Sound() string { return "woof" }
+
+// Rock has a name but makes no sound
+type Rock struct{}
+
+func (r Rock) Name() string { return "rock" }
+
+// Drawing holds shapes
+type Drawing struct {
+	Shapes []Shape ` + "`json:\"shapes\"`" + `
+	Pet    Animal  ` + "`json:\"pet\"`" + `
+}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	// Interfaces without a directive are only rendered as unions with InterfaceUnions
+	model, err := CollectModel([]string{tempDir}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := writeTypeScript(&buf, model.Types, model.Types, nil, DefaultOptions()); err != nil {
+		t.Fatalf("writeTypeScript failed: %v", err)
+	}
+	content := buf.String()
+	for _, expected := range []string{
+		"export type Shape = Circle | Square;",
+		"export type Animal = any;",
+		// The discriminator is a required string literal in each member
+		`export interface Circle {
+  kind: "circle";
+  radius: number;
+}`,
+		`export interface Square {
+  side: number;
+  /**
+   * Discriminator of the Shape union
+   */
+  kind: "Square";
+}`,
+		"shapes: Shape[];",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected TypeScript to contain %q, got:\n%s", expected, content)
+		}
+	}
+
+	opts := DefaultOptions()
+	opts.InterfaceUnions = true
+	model, err = CollectModel([]string{tempDir}, opts)
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+	buf.Reset()
+	if err := writeTypeScript(&buf, model.Types, model.Types, nil, opts); err != nil {
+		t.Fatalf("writeTypeScript failed: %v", err)
+	}
+	// Implementations are detected from the method sets, including embedded interfaces
+	if !strings.Contains(buf.String(), "export type Animal = Dog;") {
+		t.Errorf("Expected Animal to be a union of its implementations, got:\n%s", buf.String())
+	}
+
+	// OpenAPI maps the discriminator values to the member schemas
+	buf.Reset()
+	if err := GenerateOpenAPIYAML(model, &buf); err != nil {
+		t.Fatalf("GenerateOpenAPIYAML failed: %v", err)
+	}
+	expectedSchema := `    Shape:
+      description: Shape is a drawable shape
+      oneOf:
+        - $ref: "#/components/schemas/Circle"
+        - $ref: "#/components/schemas/Square"
+      discriminator:
+        propertyName: kind
+        mapping:
+          circle: "#/components/schemas/Circle"
+          Square: "#/components/schemas/Square"`
+	if !strings.Contains(buf.String(), expectedSchema) {
+		t.Errorf("Expected OpenAPI to contain %q, got:\n%s", expectedSchema, buf.String())
+	}
+
+	// Guards accept any member
+	buf.Reset()
+	if err := GenerateGuards(model, &buf, "./types"); err != nil {
+		t.Fatalf("GenerateGuards failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "return (isCircle(value) || isSquare(value));") {
+		t.Errorf("Expected the Shape guard to check the members, got:\n%s", buf.String())
+	}
+}
+
+// TestInterfaceUnionsTypeChecked tests the implementations detected by type-checking the
+// sources: promoted methods, pointer receivers, method signatures and packages importing
+// each other
+func TestInterfaceUnionsTypeChecked(t *testing.T) {
+	// Create a temporary module with two packages
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-unions-types-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	files := map[string]string{
+		"go.mod": "module example.com/notify\n\ngo 1.21\n",
+		"notify/notify.go": `package notify
+
+import "time"
+
+// Notifier sends notifications
+//ts:union
+type Notifier interface {
+	Notify(msg string, delay time.Duration) error
+}
+
+// Sender implements Notifier for the types embedding it
+type Sender struct{}
+
+func (s *Sender) Notify(msg string, delay time.Duration) error { return nil }
+
+// Email embeds the Sender methods
+type Email struct {
+	*Sender
+	To string ` + "`json:\"to\"`" + `
+}
+
+// Fake has the Notify method with other parameter types
+type Fake struct{}
+
+func (f Fake) Notify(msg string, delay int64) error { return nil }
+`,
+		"channels/channels.go": `package channels
+
+import (
+	"time"
+
+	"example.com/notify/notify"
+)
+
+// Sms implements notify.Notifier with a pointer receiver
+type Sms struct {
+	Phone string ` + "`json:\"phone\"`" + `
+}
+
+func (s *Sms) Notify(msg string, delay time.Duration) error { return nil }
+
+var _ notify.Notifier = (*Sms)(nil)
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tempDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	model, err := CollectModel([]string{tempDir}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := writeTypeScript(&buf, model.Types, model.Types, nil, DefaultOptions()); err != nil {
+		t.Fatalf("writeTypeScript failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "export type Notifier = Sms | Sender | Email;") {
+		t.Errorf("Expected Notifier to be a union of its implementations, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestInputVariants tests the input variants generated for types used in requests
+func TestInputVariants(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "go-ts-generator-variants-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a test Go file with a type used in both requests and responses
+	goFilePath := filepath.Join(tempDir, "api.go")
+	goFileContent := `package api
+
+// Address is used in requests and responses
+type Address struct {
+	ID        int64  ` + "`json:\"id\" ts:\"readonly\"`" + `
+	City      string ` + "`json:\"city\"`" + `
+	CreatedAt string ` + "`json:\"created_at\" ts:\"response-only\"`" + `
+}
+
+// UserRequest is the payload to create a user
+type UserRequest struct {
+	Name    string    ` + "`json:\"name\"`" + `
+	Address Address   ` + "`json:\"address\"`" + `
+	Others  []Address ` + "`json:\"others\"`" + `
+}
+
+// UserResponse is returned by the user endpoints
+type UserResponse struct {
+	ID      int64   ` + "`json:\"id\" ts:\"response-only\"`" + `
+	Address Address ` + "`json:\"address\"`" + `
+}
+
+// CreateUser godoc
+// @Param user body UserRequest true "User"
+// @Success 201 {object} UserResponse
+// @Router /users [post]
+func CreateUser() {}
+`
+
+	if err := os.WriteFile(goFilePath, []byte(goFileContent), 0644); err != nil {
+		t.Fatalf("Failed to write test Go file: %v", err)
+	}
+
+	model, err := CollectModel([]string{tempDir}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("CollectModel failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := writeTypeScript(&buf, model.Types, model.Types, nil, DefaultOptions()); err != nil {
+		t.Fatalf("writeTypeScript failed: %v", err)
+	}
+	content := buf.String()
+
+	for _, expected := range []string{
+		// Types used in responses keep every field
+		`export interface Address {
+  readonly id: number;
+  city: string;
+  created_at: string;
+}`,
+		`/**
+ * Address is used in requests and responses
+ *
+ * Input variant of Address without the fields set by the server
+ */
+export interface AddressInput {
+  city: string;
+}`,
+		// Types only used in requests keep their name and reference the input variants
+		`export interface UserRequest {
+  name: string;
+  address: AddressInput;
+  others: AddressInput[];
+}`,
+		`export interface UserResponse {
+  id: number;
+  address: Address;
+}`,
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected TypeScript to contain %q, got:\n%s", expected, content)
+		}
+	}
+	if strings.Contains(content, "UserRequestInput") || strings.Contains(content, "UserResponseInput") {
+		t.Errorf("Expected no input variants of the request and response types, got:\n%s", content)
+	}
+
+	// The request endpoints move to the input variants
+	for _, typ := range model.Types {
+		for _, endpoint := range typ.Endpoints {
+			if typ.Name == "Address" && endpoint.Request {
+				t.Errorf("Expected Address to have no request endpoints, got %v", typ.Endpoints)
+			}
+		}
+	}
+	if body := model.Operations[0].Parameters[0].Type.String(); body != "UserRequest" {
+		t.Errorf("Expected the body type UserRequest, got %s", body)
+	}
+}
@@ -0,0 +1,213 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// guardHelpers declares the helpers shared by the generated type guards
+const guardHelpers = `function isObject(value: unknown): value is Record<string, unknown> {
+  return typeof value === "object" && value !== null && !Array.isArray(value);
+}
+`
+
+// GenerateGuards writes dependency-free type guards (isX(value): value is X) for the types of
+// the model, importing the types from typesModule (e.g. "./types")
+func GenerateGuards(model *Model, w io.Writer, typesModule string) error {
+	return generateGuards(model, w, func(used []string) []tsImport {
+		return []tsImport{{Names: used, From: typesModule}}
+	})
+}
+
+// WriteGuards writes the type guards of the model to the target file. The types are imported
+// from typesFile, or from opts.APITypesFile for the API types when it is set.
+func WriteGuards(model *Model, targetFile, typesFile string, opts Options) error {
+	target := RenderTarget{Path: targetFile, TypesFile: typesFile, Options: opts}
+	return RenderToFile(typeModuleRenderer(target, generateGuards), model, targetFile)
+}
+
+// generateGuards writes the type guards, with the imports returned by imports for the
+// collected types
+func generateGuards(model *Model, w io.Writer, imports func(used []string) []tsImport) error {
+	g := &guardBuilder{types: make(map[string]*TypeScriptType)}
+	var refs []*TypeRef
+	for i, t := range model.Types {
+		g.types[t.Name] = &model.Types[i]
+		refs = append(refs, namedRef(t.Name))
+	}
+
+	var b strings.Builder
+	writeModuleHeader(&b, model, refs, nil, imports)
+	b.WriteString(guardHelpers)
+
+	for _, t := range model.Types {
+		var checks []string
+		if t.IsInterface {
+			checks = append([]string{"isObject(value)"}, g.fieldChecks(t.Fields, "value", nil)...)
+		} else if len(t.Fields) > 0 {
+			// Type aliases have a single "value" field holding the aliased type
+			if ref := t.Fields[0].TypeRef; ref != nil && ref.Kind == KindComposite {
+				checks = g.compositeChecks(ref, "value", 0)
+			} else if check := g.check(ref, "value", 0); check != "" {
+				checks = append(checks, check)
+			}
+		}
+		if len(checks) == 0 {
+			checks = []string{"true"}
+		}
+
+		fmt.Fprintf(&b, "\n/**\n * Checks if a value matches the %s type\n */\n", t.Name)
+		fmt.Fprintf(&b, "export function %s(value: unknown): value is %s {\n", guardName(t.Name), t.Name)
+		if len(checks) == 1 {
+			fmt.Fprintf(&b, "  return %s;\n}\n", checks[0])
+			continue
+		}
+		fmt.Fprintf(&b, "  return (\n    %s\n  );\n}\n", strings.Join(checks, " &&\n    "))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// guardBuilder builds the checks of the type guards
+type guardBuilder struct {
+	types map[string]*TypeScriptType // Collected types by name
+}
+
+// fieldChecks returns the checks of the fields of an object, except the skipped ones.
+// Optional fields may be undefined.
+func (g *guardBuilder) fieldChecks(fields []TypeScriptField, object string, skip map[string]bool) []string {
+	var checks []string
+	for _, field := range fields {
+		if skip[field.Name] {
+			continue
+		}
+		expr := object + "[" + strconv.Quote(field.Name) + "]"
+		check := g.check(field.TypeRef, expr, 0)
+		if check == "" {
+			continue
+		}
+		if field.Optional {
+			// (x === null || ...) -> (x === undefined || x === null || ...)
+			if strings.HasPrefix(check, "("+expr+" === null || ") {
+				check = "(" + expr + " === undefined || " + check[1:]
+			} else {
+				check = "(" + expr + " === undefined || " + check + ")"
+			}
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// check returns an expression checking that expr matches ref, or "" when every value matches.
+// depth numbers the parameters of nested callbacks.
+func (g *guardBuilder) check(ref *TypeRef, expr string, depth int) string {
+	if ref == nil {
+		return ""
+	}
+	switch ref.Kind {
+	case KindNamed:
+		if g.types[ref.Name] == nil {
+			// Placeholders of types that were not collected accept any value
+			return ""
+		}
+		return guardName(ref.Name) + "(" + expr + ")"
+	case KindNullable:
+		check := g.check(ref.Elem, expr, depth)
+		if check == "" {
+			return ""
+		}
+		return "(" + expr + " === null || " + check + ")"
+	case KindArray:
+		item := itemName(depth)
+		if check := g.check(ref.Elem, item, depth+1); check != "" {
+			return "(Array.isArray(" + expr + ") && " + expr + ".every((" + item + ") => " + check + "))"
+		}
+		return "Array.isArray(" + expr + ")"
+	case KindMap:
+		item := itemName(depth)
+		if check := g.check(ref.Elem, item, depth+1); check != "" {
+			return "(isObject(" + expr + ") && Object.values(" + expr + ").every((" + item + ") => " + check + "))"
+		}
+		return "isObject(" + expr + ")"
+	case KindComposite:
+		return "(" + strings.Join(g.compositeChecks(ref, expr, depth), " && ") + ")"
+	case KindUnion:
+		var checks []string
+		for _, member := range ref.Members {
+			check := g.check(member, expr, depth)
+			if check == "" {
+				return ""
+			}
+			checks = append(checks, check)
+		}
+		if len(checks) == 1 {
+			return checks[0]
+		}
+		return "(" + strings.Join(checks, " || ") + ")"
+	}
+	return primitiveCheck(ref, expr)
+}
+
+// compositeChecks returns the checks of a composite type: the fields of the base type,
+// except the overridden ones, and the overridden fields
+func (g *guardBuilder) compositeChecks(ref *TypeRef, expr string, depth int) []string {
+	checks := []string{"isObject(" + expr + ")"}
+	skip := make(map[string]bool)
+	for _, override := range ref.Overrides {
+		skip[override.Name] = true
+	}
+	if base := g.types[ref.Name]; base != nil && base.IsInterface {
+		checks = append(checks, g.fieldChecks(base.Fields, expr, skip)...)
+	}
+	for _, override := range ref.Overrides {
+		if check := g.check(override.Type, expr+"["+strconv.Quote(override.Name)+"]", depth); check != "" {
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
+
+// primitiveCheck returns the typeof checks of a primitive TypeRef, which may be a union of
+// primitive and literal types
+func primitiveCheck(ref *TypeRef, expr string) string {
+	if isTimeRef(ref) && ref.Name == "Date" {
+		return expr + " instanceof Date"
+	}
+
+	var checks []string
+	for _, name := range strings.Split(ref.Name, " | ") {
+		switch name {
+		case "string", "number", "boolean", "bigint":
+			checks = append(checks, "typeof "+expr+" === "+strconv.Quote(name))
+		case "null", "undefined":
+			checks = append(checks, expr+" === "+name)
+		case "any", "unknown":
+			return ""
+		default:
+			// Literal types: "active", 1, true
+			checks = append(checks, expr+" === "+name)
+		}
+	}
+	if len(checks) == 1 {
+		return checks[0]
+	}
+	return "(" + strings.Join(checks, " || ") + ")"
+}
+
+// itemName returns the parameter name of a callback nested depth levels deep
+func itemName(depth int) string {
+	if depth == 0 {
+		return "item"
+	}
+	return "item" + strconv.Itoa(depth+1)
+}
+
+// guardName returns the name of the type guard of a type
+// e.g. User -> isUser
+func guardName(typeName string) string {
+	return "is" + upperFirst(typeName)
+}
@@ -0,0 +1,258 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// handlerInfo represents the request and response types observed in a handler body
+type handlerInfo struct {
+	Requests  []handlerType // Types bound from the request (ShouldBindJSON, Bind, Decode, ...)
+	Responses []handlerType // Types written to the response (JSON, Encode, ...)
+}
+
+// handlerType represents a type referenced from a handler body
+type handlerType struct {
+	Name    string // Type name without package prefix
+	IsArray bool   // Whether the value is a slice of the type
+	Status  int    // HTTP status code for responses, 0 if unknown
+	Method  string // Bind or write method used (e.g. ShouldBindJSON, JSON)
+}
+
+// bindMethods lists the methods that decode the request into their last argument
+var bindMethods = map[string]bool{
+	// gin
+	"Bind":               true,
+	"BindJSON":           true,
+	"BindQuery":          true,
+	"BindUri":            true,
+	"BindXML":            true,
+	"BindYAML":           true,
+	"BindHeader":         true,
+	"BindWith":           true,
+	"ShouldBind":         true,
+	"ShouldBindJSON":     true,
+	"ShouldBindQuery":    true,
+	"ShouldBindUri":      true,
+	"ShouldBindXML":      true,
+	"ShouldBindYAML":     true,
+	"ShouldBindHeader":   true,
+	"ShouldBindWith":     true,
+	"ShouldBindBodyWith": true,
+	// encoding/json (echo uses Bind as well)
+	"Decode":    true,
+	"Unmarshal": true,
+}
+
+// writeMethods lists the methods that write their value argument as a JSON response.
+// The value is the index of the status argument, or -1 if there is none.
+var writeMethods = map[string]int{
+	"JSON":                0,
+	"IndentedJSON":        0,
+	"PureJSON":            0,
+	"SecureJSON":          0,
+	"AsciiJSON":           0,
+	"JSONP":               0,
+	"JSONPretty":          0,
+	"AbortWithStatusJSON": 0,
+	"Encode":              -1,
+}
+
+// httpStatusCodes maps net/http status constants to their numeric values
+var httpStatusCodes = map[string]int{
+	"StatusOK":                  200,
+	"StatusCreated":             201,
+	"StatusAccepted":            202,
+	"StatusNoContent":           204,
+	"StatusMovedPermanently":    301,
+	"StatusFound":               302,
+	"StatusNotModified":         304,
+	"StatusBadRequest":          400,
+	"StatusUnauthorized":        401,
+	"StatusForbidden":           403,
+	"StatusNotFound":            404,
+	"StatusMethodNotAllowed":    405,
+	"StatusConflict":            409,
+	"StatusGone":                410,
+	"StatusUnprocessableEntity": 422,
+	"StatusTooManyRequests":     429,
+	"StatusInternalServerError": 500,
+	"StatusNotImplemented":      501,
+	"StatusBadGateway":          502,
+	"StatusServiceUnavailable":  503,
+}
+
+// analyzeHandler inspects a function body and collects the types that are bound from the
+// request or written to the response
+func analyzeHandler(params *ast.FieldList, body *ast.BlockStmt) handlerInfo {
+	var info handlerInfo
+	if body == nil {
+		return info
+	}
+
+	// Map of local variable names to their declared type expressions
+	vars := make(map[string]ast.Expr)
+	if params != nil {
+		for _, field := range params.List {
+			for _, name := range field.Names {
+				vars[name.Name] = field.Type
+			}
+		}
+	}
+	writeHeaderStatus := 0
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.ValueSpec:
+			// var req T / var req = T{}
+			for i, name := range stmt.Names {
+				if stmt.Type != nil {
+					vars[name.Name] = stmt.Type
+				} else if i < len(stmt.Values) {
+					if expr := valueTypeExpr(stmt.Values[i]); expr != nil {
+						vars[name.Name] = expr
+					}
+				}
+			}
+		case *ast.AssignStmt:
+			// req := T{} / req := &T{} / req := new(T) / resp := make([]T, 0)
+			if stmt.Tok == token.DEFINE && len(stmt.Lhs) == len(stmt.Rhs) {
+				for i, lhs := range stmt.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok {
+						if expr := valueTypeExpr(stmt.Rhs[i]); expr != nil {
+							vars[ident.Name] = expr
+						}
+					}
+				}
+			}
+		case *ast.CallExpr:
+			sel, ok := stmt.Fun.(*ast.SelectorExpr)
+			if !ok || len(stmt.Args) == 0 {
+				return true
+			}
+			method := sel.Sel.Name
+
+			// net/http handlers set the status of the following Encode calls with WriteHeader
+			if method == "WriteHeader" {
+				writeHeaderStatus = statusCode(stmt.Args[0])
+				return true
+			}
+
+			if bindMethods[method] {
+				// The bound value is the last argument, except for the *With variants where the binding follows it
+				arg := stmt.Args[len(stmt.Args)-1]
+				if method == "ShouldBindWith" || method == "BindWith" || method == "ShouldBindBodyWith" {
+					arg = stmt.Args[0]
+				}
+				if ht, ok := resolveHandlerType(arg, vars); ok {
+					ht.Method = method
+					info.Requests = append(info.Requests, ht)
+				}
+				return true
+			}
+
+			if statusIndex, ok := writeMethods[method]; ok {
+				valueIndex := statusIndex + 1
+				if valueIndex >= len(stmt.Args) {
+					return true
+				}
+				if ht, ok := resolveHandlerType(stmt.Args[valueIndex], vars); ok {
+					ht.Method = method
+					if statusIndex >= 0 {
+						ht.Status = statusCode(stmt.Args[statusIndex])
+					} else {
+						ht.Status = writeHeaderStatus
+					}
+					info.Responses = append(info.Responses, ht)
+				}
+			}
+		}
+		return true
+	})
+
+	return info
+}
+
+// valueTypeExpr returns the type expression of a value used to initialise a variable
+func valueTypeExpr(expr ast.Expr) ast.Expr {
+	switch v := expr.(type) {
+	case *ast.CompositeLit:
+		return v.Type
+	case *ast.UnaryExpr:
+		if v.Op == token.AND {
+			return valueTypeExpr(v.X)
+		}
+	case *ast.CallExpr:
+		if ident, ok := v.Fun.(*ast.Ident); ok && (ident.Name == "new" || ident.Name == "make") && len(v.Args) > 0 {
+			return v.Args[0]
+		}
+	}
+	return nil
+}
+
+// resolveHandlerType resolves the type of an argument passed to a bind or write call
+func resolveHandlerType(arg ast.Expr, vars map[string]ast.Expr) (handlerType, bool) {
+	switch a := arg.(type) {
+	case *ast.UnaryExpr:
+		if a.Op == token.AND {
+			return resolveHandlerType(a.X, vars)
+		}
+	case *ast.Ident:
+		if expr, ok := vars[a.Name]; ok {
+			return handlerTypeFromExpr(expr)
+		}
+	case *ast.CompositeLit:
+		return handlerTypeFromExpr(a.Type)
+	}
+	return handlerType{}, false
+}
+
+// handlerTypeFromExpr converts a type expression into a handlerType
+func handlerTypeFromExpr(expr ast.Expr) (handlerType, bool) {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return handlerTypeFromExpr(t.X)
+	case *ast.ArrayType:
+		ht, ok := handlerTypeFromExpr(t.Elt)
+		ht.IsArray = true
+		return ht, ok
+	case *ast.Ident:
+		if isGoBuiltinType(t.Name) {
+			return handlerType{}, false
+		}
+		return handlerType{Name: t.Name}, true
+	case *ast.SelectorExpr:
+		return handlerType{Name: t.Sel.Name}, true
+	}
+	return handlerType{}, false
+}
+
+// statusCode extracts an HTTP status code from an int literal or a net/http constant
+func statusCode(expr ast.Expr) int {
+	switch s := expr.(type) {
+	case *ast.BasicLit:
+		code := 0
+		for _, r := range s.Value {
+			if r < '0' || r > '9' {
+				return 0
+			}
+			code = code*10 + int(r-'0')
+		}
+		return code
+	case *ast.SelectorExpr:
+		return httpStatusCodes[s.Sel.Name]
+	}
+	return 0
+}
+
+// isGoBuiltinType checks if a name refers to a predeclared Go type
+func isGoBuiltinType(name string) bool {
+	switch name {
+	case "string", "bool", "byte", "rune", "error", "any",
+		"int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+		"float32", "float64", "complex64", "complex128":
+		return true
+	}
+	return false
+}
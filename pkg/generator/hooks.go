@@ -0,0 +1,518 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// HookLibrary is a React data fetching library the hooks are generated for
+type HookLibrary string
+
+const (
+	// HooksTanStack generates useQuery/useMutation hooks of TanStack Query (v5)
+	HooksTanStack HookLibrary = "tanstack"
+	// HooksSWR generates useSWR/useSWRMutation hooks of SWR (v2)
+	HooksSWR HookLibrary = "swr"
+)
+
+// hookFetcher declares the fetcher used by the request functions, which applications
+// replace with setApiFetcher to add a base URL, authentication or other encodings
+const hookFetcher = `export interface ApiRequest {
+  method: string;
+  path: string;
+  query?: object;
+  body?: unknown;
+}
+
+export type ApiFetcher = <T>(request: ApiRequest) => Promise<T>;
+
+/**
+ * Sends the request as JSON with fetch, appending the query parameters to the path
+ */
+export const defaultApiFetcher: ApiFetcher = async <T>(request: ApiRequest): Promise<T> => {
+  const search = new URLSearchParams();
+  for (const [key, value] of Object.entries(request.query ?? {})) {
+    for (const item of Array.isArray(value) ? value : [value]) {
+      if (item !== undefined && item !== null) search.append(key, String(item));
+    }
+  }
+  const queryString = search.toString();
+  const url = queryString ? ` + "`${request.path}?${queryString}`" + ` : request.path;
+  const response = await fetch(url, {
+    method: request.method,
+    headers: request.body === undefined ? undefined : { "Content-Type": "application/json" },
+    body: request.body === undefined ? undefined : JSON.stringify(request.body),
+  });
+  if (!response.ok) {
+    throw new Error(` + "`${request.method} ${request.path} failed with status ${response.status}`" + `);
+  }
+  return (response.status === 204 ? undefined : await response.json()) as T;
+};
+
+let apiFetcher: ApiFetcher = defaultApiFetcher;
+
+/**
+ * Replaces the fetcher used by the request functions and hooks
+ */
+export function setApiFetcher(fetcher: ApiFetcher): void {
+  apiFetcher = fetcher;
+}
+`
+
+// reservedIdentifiers lists the names that cannot be used as names of the generated
+// functions or their parameters
+var reservedIdentifiers = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true, "continue": true,
+	"debugger": true, "default": true, "delete": true, "do": true, "else": true, "enum": true,
+	"export": true, "extends": true, "false": true, "finally": true, "for": true, "function": true,
+	"if": true, "import": true, "in": true, "instanceof": true, "new": true, "null": true,
+	"return": true, "super": true, "switch": true, "this": true, "throw": true, "true": true,
+	"try": true, "typeof": true, "var": true, "void": true, "while": true, "with": true,
+	// Reserved in strict mode and ES modules
+	"await": true, "implements": true, "interface": true, "let": true, "package": true,
+	"private": true, "protected": true, "public": true, "static": true, "yield": true,
+	// Parameters of the generated functions
+	"query": true, "body": true, "options": true, "config": true, "variables": true,
+}
+
+// hookOperation describes the request function and hook generated for an operation
+type hookOperation struct {
+	routeEntry
+	Name       string   // Name of the request function, e.g. getUser
+	Method     string   // Upper-case HTTP method
+	PathExpr   string   // Template literal building the path
+	PathArgs   []string // Identifiers of the path parameters
+	KeyItems   []string // Items of the query key
+	IsQuery    bool
+	HasQuery   bool
+	HasBody    bool
+	Parameters []string // Parameters of the request function
+	BodyExpr   string   // Expression serializing the dates of the body, if any
+	ParseExpr  string   // Expression parsing the dates of the decoded response json, if any
+}
+
+// GenerateHooks writes the request functions, query keys and React hooks of the model's
+// operations for the library, importing the collected types from typesModule (e.g. "./types")
+func GenerateHooks(model *Model, w io.Writer, typesModule string, library HookLibrary) error {
+	return generateHooks(model, w, library, func(used []string) []tsImport {
+		return []tsImport{{Names: used, From: typesModule}}
+	})
+}
+
+// WriteHooks writes the React hooks of the model to the target file. The types are imported
+// from typesFile, or from opts.APITypesFile for the API types when it is set.
+func WriteHooks(model *Model, targetFile, typesFile string, library HookLibrary, opts Options) error {
+	renderer, err := LookupRenderer(string(library), RenderTarget{Path: targetFile, TypesFile: typesFile, Options: opts})
+	if err != nil {
+		return err
+	}
+	return RenderToFile(renderer, model, targetFile)
+}
+
+// generateHooks writes the hooks module, with the imports returned by imports for the
+// collected types used by the operations
+func generateHooks(model *Model, w io.Writer, library HookLibrary, imports func(used []string) []tsImport) error {
+	var libraryImports []string
+	switch library {
+	case HooksTanStack:
+		libraryImports = []string{
+			`import { useMutation, useQuery, type UseMutationOptions, type UseQueryOptions } from "@tanstack/react-query";`,
+		}
+	case HooksSWR:
+		libraryImports = []string{
+			`import useSWR, { type SWRConfiguration } from "swr";`,
+			`import useSWRMutation, { type SWRMutationConfiguration } from "swr/mutation";`,
+		}
+	default:
+		return fmt.Errorf("unknown hook library %q", library)
+	}
+
+	// GET operations become queries, POST, PUT, PATCH and DELETE operations mutations
+	var operations []hookOperation
+	var entries []routeEntry
+	seenKeys := make(map[string]bool)
+	names := make(map[string]bool)
+	// With the Date mode, bodies and responses are converted with the helpers of the types file
+	dates := usesDateType(model.Types)
+	var dateTypes map[string]bool
+	if dates {
+		dateTypes = dateTypeNames(model.Types)
+	}
+	for _, op := range model.Operations {
+		switch op.Method {
+		case "get", "post", "put", "patch", "delete":
+		default:
+			continue
+		}
+		if dates {
+			op = dateOperation(op)
+		}
+		hookOp := newHookOperation(op)
+		if dates {
+			hookOp.addDateConversions(op, model.Types, dateTypes)
+		}
+		if seenKeys[hookOp.Key] {
+			continue
+		}
+		seenKeys[hookOp.Key] = true
+
+		// Number the functions of operations sharing a name
+		name := hookOp.Name
+		for i := 2; names[name]; i++ {
+			name = hookOp.Name + strconv.Itoa(i)
+		}
+		names[name] = true
+		hookOp.Name = name
+
+		operations = append(operations, hookOp)
+		entries = append(entries, hookOp.routeEntry)
+	}
+
+	var b strings.Builder
+	writeModuleHeader(&b, model, entryRefs(entries), libraryImports, func(used []string) []tsImport {
+		return dateHelperImports(imports(used), operations, dateTypes)
+	})
+
+	b.WriteString(hookFetcher)
+	for _, op := range operations {
+		if strings.Contains(op.BodyExpr+op.ParseExpr, "mapRecord(") {
+			b.WriteString("\n" + mapRecordHelper)
+			break
+		}
+	}
+
+	// Query key factories, starting with the path segments so that the keys of a resource
+	// can be invalidated together, e.g. queryClient.invalidateQueries({ queryKey: ["users"] })
+	b.WriteString("\n/**\n * Query keys of the GET endpoints, derived from their paths\n */\nexport const queryKeys = {\n")
+	for _, op := range operations {
+		if op.IsQuery {
+			fmt.Fprintf(&b, "  %s: (%s) => [%s] as const,\n", op.Name, strings.Join(op.Parameters, ", "), strings.Join(op.KeyItems, ", "))
+		}
+	}
+	b.WriteString("};\n")
+
+	for _, op := range operations {
+		b.WriteString("\n")
+		writeRequestFunction(&b, op)
+		b.WriteString("\n")
+		if op.IsQuery {
+			writeQueryHook(&b, op, library)
+		} else {
+			writeMutationHook(&b, op, library)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// newHookOperation describes the request function and hook of an operation
+func newHookOperation(op Operation) hookOperation {
+	hookOp := hookOperation{
+		routeEntry: newRouteEntry(op),
+		Name:       operationFunctionName(op),
+		Method:     strings.ToUpper(op.Method),
+		IsQuery:    op.Method == "get",
+	}
+	hookOp.HasQuery = hookOp.Query != emptyObjectType
+	hookOp.HasBody = hookOp.Body != "undefined"
+
+	// Path parameters become positional parameters, followed by the body and the query
+	identifiers := make(map[string]string)
+	for _, param := range hookOp.PathParams {
+		ident := paramIdentifier(param.Name)
+		identifiers[param.Name] = ident
+		hookOp.PathArgs = append(hookOp.PathArgs, ident)
+		hookOp.Parameters = append(hookOp.Parameters, ident+": "+param.Type)
+	}
+	if hookOp.HasBody {
+		hookOp.Parameters = append(hookOp.Parameters, "body: "+hookOp.Body)
+	}
+	if hookOp.HasQuery {
+		if hookOp.QueryRequired {
+			hookOp.Parameters = append(hookOp.Parameters, "query: "+hookOp.Query)
+		} else {
+			hookOp.Parameters = append(hookOp.Parameters, "query?: "+hookOp.Query)
+		}
+	}
+
+	// Build the path and the query key from the path segments
+	var path strings.Builder
+	for _, segment := range strings.Split(strings.TrimPrefix(op.Path, "/"), "/") {
+		path.WriteString("/")
+		if match := pathParamRegex.FindStringSubmatch(segment); match != nil && match[0] == segment {
+			path.WriteString("${encodeURIComponent(String(" + identifiers[match[1]] + "))}")
+			hookOp.KeyItems = append(hookOp.KeyItems, identifiers[match[1]])
+			continue
+		}
+		path.WriteString(segment)
+		if segment != "" {
+			hookOp.KeyItems = append(hookOp.KeyItems, strconv.Quote(segment))
+		}
+	}
+	hookOp.PathExpr = "`" + path.String() + "`"
+	if len(hookOp.KeyItems) == 0 {
+		hookOp.KeyItems = []string{strconv.Quote(op.Path)}
+	}
+	if hookOp.HasQuery {
+		hookOp.KeyItems = append(hookOp.KeyItems, "query")
+	}
+	return hookOp
+}
+
+// dateOperation returns a copy of the operation whose body and response types render
+// time.Time as Date
+func dateOperation(op Operation) Operation {
+	op.Parameters = slices.Clone(op.Parameters)
+	for i, param := range op.Parameters {
+		if param.In == "body" {
+			op.Parameters[i].Type = dateRef(param.Type)
+		}
+	}
+	op.Responses = slices.Clone(op.Responses)
+	for i, resp := range op.Responses {
+		op.Responses[i].Type = dateRef(resp.Type)
+	}
+	return op
+}
+
+// addDateConversions converts the dates of the body with the serializeX helpers and those of
+// the response with the parseX helpers. Responses of several types cannot be told apart and
+// are returned as decoded.
+func (op *hookOperation) addDateConversions(source Operation, types []TypeScriptType, dateTypes map[string]bool) {
+	for _, param := range source.Parameters {
+		if param.In == "body" && refHasDates(param.Type, dateTypes) {
+			op.BodyExpr = dateConversion(param.Type, "body", false, types, dateTypes, 0)
+		}
+	}
+
+	var response *TypeRef
+	for _, resp := range source.Responses {
+		if resp.Failure || !strings.HasPrefix(resp.Status, "2") {
+			continue
+		}
+		if resp.Type == nil || (response != nil && response.String() != resp.Type.String()) {
+			return
+		}
+		response = resp.Type
+	}
+	if response != nil && refHasDates(response, dateTypes) {
+		op.ParseExpr = dateConversion(response, "json", true, types, dateTypes, 0)
+	}
+}
+
+// dateHelperImports adds the date helpers called by the operations to the imports of the
+// types they convert
+func dateHelperImports(imports []tsImport, operations []hookOperation, dateTypes map[string]bool) []tsImport {
+	var calls strings.Builder
+	for _, op := range operations {
+		calls.WriteString(op.BodyExpr + "\n" + op.ParseExpr + "\n")
+	}
+	for i, imp := range imports {
+		for _, name := range imp.Names {
+			if !dateTypes[name] {
+				continue
+			}
+			parseName, serializeName := dateHelperNames(name)
+			for _, helper := range []string{parseName, serializeName} {
+				if strings.Contains(calls.String(), helper+"(") {
+					imports[i].Values = append(imports[i].Values, helper)
+				}
+			}
+		}
+	}
+	return imports
+}
+
+// writeRequestFunction writes the function sending the request of an operation
+func writeRequestFunction(b *strings.Builder, op hookOperation) {
+	comment := op.Method + " " + op.Path()
+	if op.Comment != "" {
+		comment = op.Comment + " (" + comment + ")"
+	}
+	fields := []string{"method: " + strconv.Quote(op.Method), "path: " + op.PathExpr}
+	if op.HasQuery {
+		fields = append(fields, "query")
+	}
+	if op.BodyExpr != "" {
+		fields = append(fields, "body: "+op.BodyExpr)
+	} else if op.HasBody {
+		fields = append(fields, "body")
+	}
+	fmt.Fprintf(b, "/** %s */\n", comment)
+	fmt.Fprintf(b, "export function %s(%s): Promise<%s> {\n", op.Name, strings.Join(op.Parameters, ", "), op.Response)
+	if op.ParseExpr != "" {
+		fmt.Fprintf(b, "  return apiFetcher<any>({ %s }).then((json) => %s);\n", strings.Join(fields, ", "), op.ParseExpr)
+	} else {
+		fmt.Fprintf(b, "  return apiFetcher<%s>({ %s });\n", op.Response, strings.Join(fields, ", "))
+	}
+	b.WriteString("}\n")
+}
+
+// writeQueryHook writes the query hook of a GET operation
+func writeQueryHook(b *strings.Builder, op hookOperation, library HookLibrary) {
+	hookName := "use" + upperFirst(op.Name)
+	args := strings.Join(op.callArguments(), ", ")
+	key := "queryKeys." + op.Name + "(" + args + ")"
+	parameters := ""
+	for _, param := range op.Parameters {
+		parameters += param + ", "
+	}
+
+	switch library {
+	case HooksTanStack:
+		var lines []string
+		for _, param := range op.Parameters {
+			lines = append(lines, "  "+param+",\n")
+		}
+		fmt.Fprintf(b, "export function %s(\n%s  options?: Omit<UseQueryOptions<%s, Error, %s, ReturnType<typeof queryKeys.%s>>, \"queryKey\" | \"queryFn\">,\n) {\n",
+			hookName, strings.Join(lines, ""), op.Response, op.Response, op.Name)
+		fmt.Fprintf(b, "  return useQuery({ queryKey: %s, queryFn: () => %s(%s), ...options });\n", key, op.Name, args)
+	case HooksSWR:
+		fmt.Fprintf(b, "export function %s(%sconfig?: SWRConfiguration<%s, Error>) {\n", hookName, parameters, op.Response)
+		fmt.Fprintf(b, "  return useSWR(%s, () => %s(%s), config);\n", key, op.Name, args)
+	}
+	b.WriteString("}\n")
+}
+
+// writeMutationHook writes the variables type and the mutation hook of an operation
+func writeMutationHook(b *strings.Builder, op hookOperation, library HookLibrary) {
+	hookName := "use" + upperFirst(op.Name)
+	variablesName := upperFirst(op.Name) + "Variables"
+
+	// The variables group the parameters of the request function
+	variables := "void"
+	var call []string
+	if len(op.Parameters) > 0 {
+		fmt.Fprintf(b, "export type %s = { %s };\n\n", variablesName, strings.Join(op.Parameters, "; "))
+		variables = variablesName
+		for _, arg := range op.callArguments() {
+			call = append(call, "variables."+arg)
+		}
+	}
+	mutationFn := fmt.Sprintf("() => %s()", op.Name)
+	if variables != "void" {
+		mutationFn = fmt.Sprintf("(variables: %s) => %s(%s)", variables, op.Name, strings.Join(call, ", "))
+	}
+
+	switch library {
+	case HooksTanStack:
+		fmt.Fprintf(b, "export function %s(options?: Omit<UseMutationOptions<%s, Error, %s>, \"mutationFn\">) {\n", hookName, op.Response, variables)
+		fmt.Fprintf(b, "  return useMutation({ mutationFn: %s, ...options });\n", mutationFn)
+	case HooksSWR:
+		if variables != "void" {
+			mutationFn = fmt.Sprintf("(_key: string, { arg: variables }: { arg: %s }) => %s(%s)", variables, op.Name, strings.Join(call, ", "))
+		}
+		fmt.Fprintf(b, "export function %s(config?: SWRMutationConfiguration<%s, Error, string, %s>) {\n", hookName, op.Response, variables)
+		fmt.Fprintf(b, "  return useSWRMutation(%s, %s, config);\n", strconv.Quote(op.Key), mutationFn)
+	}
+	b.WriteString("}\n")
+}
+
+// Path returns the path of the operation
+func (op hookOperation) Path() string {
+	_, path, _ := strings.Cut(op.Key, " ")
+	return path
+}
+
+// callArguments returns the arguments passing the parameters of a hook to its request function
+func (op hookOperation) callArguments() []string {
+	args := append([]string{}, op.PathArgs...)
+	if op.HasBody {
+		args = append(args, "body")
+	}
+	if op.HasQuery {
+		args = append(args, "query")
+	}
+	return args
+}
+
+// operationFunctionName returns the name of the request function of an operation, derived
+// from its operation ID, its handler or its method and path
+// e.g. GET /users/{id} -> getUsersById, @ID delete -> deleteRequest
+func operationFunctionName(op Operation) string {
+	var words []string
+	switch {
+	case op.ID != "":
+		words = identifierWords(op.ID)
+	case op.Handler != "":
+		words = identifierWords(op.Handler)
+	default:
+		words = []string{op.Method}
+		for _, segment := range strings.Split(op.Path, "/") {
+			if match := pathParamRegex.FindStringSubmatch(segment); match != nil {
+				words = append(words, "by")
+				words = append(words, identifierWords(match[1])...)
+			} else {
+				words = append(words, identifierWords(segment)...)
+			}
+		}
+	}
+	if len(words) == 0 {
+		return op.Method
+	}
+
+	name := lowerFirst(words[0])
+	for _, word := range words[1:] {
+		name += upperFirst(word)
+	}
+	if unicode.IsDigit(rune(name[0])) {
+		name = op.Method + upperFirst(name)
+	}
+	if reservedIdentifiers[name] {
+		name += "Request"
+	}
+	return name
+}
+
+// paramIdentifier returns the identifier of a path parameter
+// e.g. room_id -> roomId, default -> defaultParam
+func paramIdentifier(name string) string {
+	words := identifierWords(name)
+	if len(words) == 0 {
+		return "param"
+	}
+	ident := lowerFirst(words[0])
+	for _, word := range words[1:] {
+		ident += upperFirst(word)
+	}
+	if unicode.IsDigit(rune(ident[0])) {
+		ident = "param" + ident
+	}
+	if reservedIdentifiers[ident] {
+		ident += "Param"
+	}
+	return ident
+}
+
+// identifierWords splits a name into the words of an identifier, dropping the characters
+// that cannot be used in identifiers
+func identifierWords(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// upperFirst returns s with its first letter in upper case
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// lowerFirst returns s with its first letter in lower case
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Generator collects the model of a set of source directories once and renders it. The
+// methods are safe for concurrent use by multiple goroutines.
+type Generator struct {
+	opts Options
+
+	mu    sync.RWMutex
+	model *Model
+}
+
+// New returns a Generator using the default options changed by the functional options
+func New(options ...Option) *Generator {
+	return &Generator{opts: applyOptions(options)}
+}
+
+// Options returns the options of the generator
+func (g *Generator) Options() Options {
+	return g.opts
+}
+
+// Load collects the model from the source directories, replacing the model of a previous
+// call. The context is checked while walking the directories; a cancelled Load keeps the
+// previous model and returns the context error.
+func (g *Generator) Load(ctx context.Context, dirs ...string) error {
+	model, err := collectModel(ctx, dirs, g.opts)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.model = model
+	return nil
+}
+
+// Model returns the loaded model, or nil before the first Load. The model is shared by the
+// callers and must not be modified.
+func (g *Generator) Model() *Model {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.model
+}
+
+// Render writes the TypeScript definitions of the loaded model
+func (g *Generator) Render(w io.Writer) error {
+	model, err := g.loadedModel()
+	if err != nil {
+		return err
+	}
+	return TypeScriptRenderer{Options: g.opts}.Render(model, w)
+}
+
+// WriteFile writes the TypeScript definitions of the loaded model to the target file, and
+// the API types to Options.APITypesFile when it is set
+func (g *Generator) WriteFile(path string) error {
+	model, err := g.loadedModel()
+	if err != nil {
+		return err
+	}
+	return RenderToFile(TypeScriptRenderer{Options: g.opts}, model, path)
+}
+
+// loadedModel returns the loaded model, or an error before the first Load
+func (g *Generator) loadedModel() (*Model, error) {
+	model := g.Model()
+	if model == nil {
+		return nil, fmt.Errorf("no model loaded, call Load first")
+	}
+	return model, nil
+}
+
+// packageKey is the context key of the package collected by walkContext, from Options.Package
+type packageKey struct{}
+
+// withPackage returns a context restricting walkContext to the files of the package, or ctx
+// when the package name is empty
+func withPackage(ctx context.Context, pkgName string) context.Context {
+	if pkgName == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, packageKey{}, pkgName)
+}
+
+// walkContext walks the file tree like filepath.Walk, returning the context error once the
+// context is cancelled. With a package set by withPackage, the subdirectories of the root and
+// the Go files of other packages are skipped.
+func walkContext(ctx context.Context, root string, fn filepath.WalkFunc) error {
+	pkgName, _ := ctx.Value(packageKey{}).(string)
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if pkgName != "" && err == nil {
+			if info.IsDir() && path != root {
+				return filepath.SkipDir
+			}
+			if !info.IsDir() && strings.HasSuffix(path, ".go") && !inPackage(path, pkgName) {
+				return nil
+			}
+		}
+		return fn(path, info, err)
+	})
+}
+
+// inPackage checks if the package clause of a Go file names the package. Files that cannot
+// be parsed are reported by the walk functions.
+func inPackage(path, pkgName string) bool {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.PackageClauseOnly)
+	return err != nil || file.Name.Name == pkgName
+}
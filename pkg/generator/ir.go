@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// IRVersion is the version of the intermediate representation written by SaveModel. It is
+// increased when fields are renamed, removed or change meaning; added fields keep the version.
+const IRVersion = 1
+
+// irDocument is the JSON document of the intermediate representation
+type irDocument struct {
+	Version int `json:"version"`
+	*Model
+}
+
+// sourcePosition returns the position of a node in the Go sources
+func sourcePosition(fset *token.FileSet, pos token.Pos) *Position {
+	position := fset.Position(pos)
+	return &Position{File: filepath.ToSlash(position.Filename), Line: position.Line, Column: position.Column}
+}
+
+// goTypeExpr returns the Go source of a type expression
+func goTypeExpr(expr ast.Expr) string {
+	return types.ExprString(expr)
+}
+
+// packagePathCache caches the import paths of the package directories
+type packagePathCache map[string]string
+
+// lookup returns the import path of the Go package in dir, derived from the module path
+// of the nearest go.mod, or the slash-separated directory outside a module
+func (c packagePathCache) lookup(dir string) string {
+	if path, ok := c[dir]; ok {
+		return path
+	}
+	path := filepath.ToSlash(dir)
+	if abs, err := filepath.Abs(dir); err == nil {
+		if root, module := findModule(abs); module != "" {
+			path = module
+			if rel, err := filepath.Rel(root, abs); err == nil && rel != "." {
+				path += "/" + filepath.ToSlash(rel)
+			}
+		}
+	}
+	c[dir] = path
+	return path
+}
+
+// findModule returns the root directory and module path of the nearest go.mod above the
+// absolute directory dir, or empty strings outside a module
+func findModule(dir string) (root, module string) {
+	for root = dir; ; root = filepath.Dir(root) {
+		if data, err := os.ReadFile(filepath.Join(root, "go.mod")); err == nil {
+			if module = modulePath(data); module != "" {
+				return root, module
+			}
+			return "", ""
+		}
+		if filepath.Dir(root) == root {
+			return "", ""
+		}
+	}
+}
+
+// modulePath returns the module path declared in a go.mod file
+func modulePath(gomod []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(gomod))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "module") {
+			continue
+		}
+		module := strings.TrimSpace(strings.TrimPrefix(line, "module"))
+		if comment := strings.Index(module, "//"); comment >= 0 {
+			module = strings.TrimSpace(module[:comment])
+		}
+		if unquoted, err := strconv.Unquote(module); err == nil {
+			module = unquoted
+		}
+		return module
+	}
+	return ""
+}
@@ -0,0 +1,425 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// JSONSchemaDialect is the JSON Schema dialect of the generated documents
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// GenerateJSONSchema writes a JSON Schema (draft 2020-12) document describing the types of
+// the model. Every type is a definition under $defs, and fields referencing other types use
+// $ref to them.
+func GenerateJSONSchema(model *Model, w io.Writer) error {
+	doc := newJSONObject()
+	doc.Set("$schema", JSONSchemaDialect)
+	doc.Set("$defs", newSchemaBuilder(model.Types, "#/$defs/", false).typeSchemas())
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding JSON Schema: %v", err)
+	}
+	data = append(data, '\n')
+	_, err = w.Write(data)
+	return err
+}
+
+// schemaBuilder converts collected types into JSON Schema objects
+type schemaBuilder struct {
+	types     []TypeScriptType
+	known     map[string]bool // Names of the collected types
+	refPrefix string          // Prefix of $ref values (e.g. #/$defs/)
+	openAPI   bool            // Use OpenAPI formats (int32, int64, byte) instead of JSON Schema keywords
+}
+
+// newSchemaBuilder creates a schemaBuilder for the given types
+func newSchemaBuilder(types []TypeScriptType, refPrefix string, openAPI bool) *schemaBuilder {
+	known := make(map[string]bool)
+	for _, t := range types {
+		known[t.Name] = true
+	}
+	return &schemaBuilder{types: types, known: known, refPrefix: refPrefix, openAPI: openAPI}
+}
+
+// typeSchemas returns the schemas of all types keyed by type name
+func (b *schemaBuilder) typeSchemas() *jsonObject {
+	schemas := newJSONObject()
+	for _, t := range b.types {
+		schemas.Set(t.Name, b.typeSchema(t))
+	}
+	return schemas
+}
+
+// typeSchema returns the schema of a single type
+func (b *schemaBuilder) typeSchema(t TypeScriptType) *jsonObject {
+	schema := newJSONObject()
+	if comment := strings.TrimSpace(t.Comment); comment != "" {
+		schema.Set("description", comment)
+	}
+
+	if !t.IsInterface {
+		// Type aliases have a single "value" field holding the aliased type
+		if len(t.Fields) > 0 {
+			mergeSchema(schema, b.fieldSchema(t.Fields[0]))
+		}
+		return schema
+	}
+
+	schema.Set("type", "object")
+	properties := newJSONObject()
+	required := []string{}
+	for _, field := range t.Fields {
+		property := b.fieldSchema(field)
+		if comment := strings.TrimSpace(field.Comment); comment != "" {
+			described := newJSONObject().Set("description", comment)
+			property = mergeSchema(described, property)
+		}
+		properties.Set(field.Name, property)
+		if !field.Optional {
+			required = append(required, field.Name)
+		}
+	}
+	schema.Set("properties", properties)
+	if len(required) > 0 {
+		schema.Set("required", required)
+	}
+	return schema
+}
+
+// fieldSchema returns the schema of a field, including the constraints of its validate
+// and binding tags
+func (b *schemaBuilder) fieldSchema(field TypeScriptField) *jsonObject {
+	schema := b.refSchema(field.TypeRef)
+	var rules []string
+	for _, key := range []string{"binding", "validate"} {
+		if value := extractTag(field.Tag, key); value != "" {
+			rules = append(rules, strings.Split(value, ",")...)
+		}
+	}
+	applyValidationRules(schema, field.TypeRef, rules)
+	return schema
+}
+
+// refSchema returns the schema of a TypeRef
+func (b *schemaBuilder) refSchema(ref *TypeRef) *jsonObject {
+	schema := newJSONObject()
+	if ref == nil {
+		return schema
+	}
+
+	switch ref.Kind {
+	case KindNamed:
+		if b.known[ref.Name] {
+			schema.Set("$ref", b.refPrefix+ref.Name)
+		}
+		// Types that were not collected are placeholders accepting any value
+	case KindArray:
+		schema.Set("type", "array")
+		schema.Set("items", b.refSchema(ref.Elem))
+	case KindMap:
+		schema.Set("type", "object")
+		// JSON object keys are always strings; integer keys are encoded as decimal strings
+		if ref.Key != nil && ref.Key.Kind == KindPrimitive && ref.Key.Name == "number" {
+			schema.Set("propertyNames", newJSONObject().Set("pattern", "^-?[0-9]+$"))
+		}
+		schema.Set("additionalProperties", b.refSchema(ref.Elem))
+	case KindNullable:
+		return nullableSchema(b.refSchema(ref.Elem))
+	case KindComposite:
+		// The overridden fields are combined with the schema of the base type
+		properties := newJSONObject()
+		var required []string
+		for _, override := range ref.Overrides {
+			properties.Set(override.Name, b.refSchema(override.Type))
+			required = append(required, override.Name)
+		}
+		overrides := newJSONObject().Set("type", "object").Set("properties", properties).Set("required", required)
+		base := newJSONObject()
+		if b.known[ref.Name] {
+			base.Set("$ref", b.refPrefix+ref.Name)
+		}
+		schema.Set("allOf", []any{base, overrides})
+	case KindUnion:
+		members := make([]any, len(ref.Members))
+		for i, member := range ref.Members {
+			members[i] = b.refSchema(member)
+		}
+		schema.Set("oneOf", members)
+		if b.openAPI && ref.Discriminator != "" {
+			schema.Set("discriminator", b.discriminatorObject(ref))
+		}
+	default:
+		b.primitiveSchema(schema, ref)
+	}
+	return schema
+}
+
+// discriminatorObject returns the OpenAPI discriminator of a union, mapping the literal value
+// of the discriminator property of each member to its schema
+func (b *schemaBuilder) discriminatorObject(ref *TypeRef) *jsonObject {
+	discriminator := newJSONObject().Set("propertyName", ref.Discriminator)
+	mapping := newJSONObject()
+	for _, member := range ref.Members {
+		for _, t := range b.types {
+			if value, ok := discriminatorValue(t, ref.Discriminator); ok && t.Name == member.Name {
+				mapping.Set(value, b.refPrefix+t.Name)
+			}
+		}
+	}
+	if mapping.Len() > 0 {
+		discriminator.Set("mapping", mapping)
+	}
+	return discriminator
+}
+
+// primitiveSchema sets the type and format of a primitive TypeRef on the schema
+func (b *schemaBuilder) primitiveSchema(schema *jsonObject, ref *TypeRef) {
+	// time.Time is a string on the wire even when rendered as Date in TypeScript
+	if isTimeRef(ref) {
+		schema.Set("type", "string")
+		schema.Set("format", "date-time")
+		return
+	}
+
+	// String literal types: "circle" | "square"
+	var literals []any
+	for _, name := range strings.Split(ref.Name, " | ") {
+		value, err := strconv.Unquote(name)
+		if err != nil || !strings.HasPrefix(name, "\"") {
+			literals = nil
+			break
+		}
+		literals = append(literals, value)
+	}
+	if len(literals) == 1 {
+		schema.Set("type", "string")
+		schema.Set("const", literals[0])
+		return
+	}
+	if len(literals) > 1 {
+		schema.Set("type", "string")
+		schema.Set("enum", literals)
+		return
+	}
+
+	var types []string
+	for _, name := range strings.Split(ref.Name, " | ") {
+		switch name {
+		case "string":
+			types = append(types, "string")
+		case "boolean":
+			types = append(types, "boolean")
+		case "null":
+			types = append(types, "null")
+		case "number":
+			if ref.Format == "int32" || ref.Format == "int64" {
+				types = append(types, "integer")
+			} else {
+				types = append(types, "number")
+			}
+		default:
+			// any and unknown TypeScript types accept any value
+			return
+		}
+	}
+	if len(types) == 1 {
+		schema.Set("type", types[0])
+	} else {
+		schema.Set("type", types)
+	}
+
+	switch ref.Format {
+	case "":
+	case "int32", "int64", "float", "double":
+		// Numeric formats are only defined by OpenAPI
+		if b.openAPI {
+			schema.Set("format", ref.Format)
+		}
+	case "byte":
+		if b.openAPI {
+			schema.Set("format", "byte")
+		} else {
+			schema.Set("contentEncoding", "base64")
+		}
+	default:
+		schema.Set("format", ref.Format)
+	}
+}
+
+// nullableSchema returns a schema that also accepts null
+func nullableSchema(schema *jsonObject) *jsonObject {
+	if schema.Len() == 0 {
+		// The empty schema already accepts null
+		return schema
+	}
+	if value, ok := schema.Get("type"); ok {
+		switch t := value.(type) {
+		case string:
+			schema.Set("type", []string{t, "null"})
+			return schema
+		case []string:
+			schema.Set("type", append(t, "null"))
+			return schema
+		}
+	}
+	return newJSONObject().Set("anyOf", []any{schema, newJSONObject().Set("type", "null")})
+}
+
+// mergeSchema copies the keys of src into dst and returns dst
+func mergeSchema(dst, src *jsonObject) *jsonObject {
+	for _, key := range src.keys {
+		dst.Set(key, src.values[key])
+	}
+	return dst
+}
+
+// validationPatterns maps validator tags to equivalent regular expressions
+var validationPatterns = map[string]string{
+	"alpha":    "^[a-zA-Z]+$",
+	"alphanum": "^[a-zA-Z0-9]+$",
+	"numeric":  "^[-+]?[0-9]+(?:\\.[0-9]+)?$",
+	"number":   "^[0-9]+$",
+}
+
+// validationFormats maps validator tags to JSON Schema formats
+var validationFormats = map[string]string{
+	"email":            "email",
+	"url":              "uri",
+	"uri":              "uri",
+	"uuid":             "uuid",
+	"uuid3":            "uuid",
+	"uuid4":            "uuid",
+	"uuid5":            "uuid",
+	"ipv4":             "ipv4",
+	"ipv6":             "ipv6",
+	"hostname":         "hostname",
+	"hostname_rfc1123": "hostname",
+}
+
+// applyValidationRules translates go-playground/validator rules into JSON Schema
+// constraints. Rules after "dive" apply to the elements of slices and maps. Rules that
+// have no JSON Schema equivalent are ignored.
+func applyValidationRules(schema *jsonObject, ref *TypeRef, rules []string) {
+	if len(rules) == 0 || ref == nil {
+		return
+	}
+
+	// Constraints of nullable references apply to the referenced schema
+	if ref.Kind == KindNullable {
+		if anyOf, ok := schema.Get("anyOf"); ok {
+			schema = anyOf.([]any)[0].(*jsonObject)
+		}
+		ref = ref.Elem
+	}
+
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+
+		switch name {
+		case "dive":
+			var elemSchema any
+			var ok bool
+			switch ref.Kind {
+			case KindArray:
+				elemSchema, ok = schema.Get("items")
+			case KindMap:
+				elemSchema, ok = schema.Get("additionalProperties")
+			}
+			if ok {
+				applyValidationRules(elemSchema.(*jsonObject), ref.Elem, rules[i+1:])
+			}
+			return
+		case "min", "gte":
+			setBound(schema, ref, param, true, 0)
+		case "max", "lte":
+			setBound(schema, ref, param, false, 0)
+		case "gt":
+			setBound(schema, ref, param, true, 1)
+		case "lt":
+			setBound(schema, ref, param, false, 1)
+		case "len":
+			setBound(schema, ref, param, true, 0)
+			setBound(schema, ref, param, false, 0)
+		case "eq":
+			schema.Set("const", ruleValue(ref, param))
+		case "oneof":
+			var values []any
+			for _, value := range strings.Fields(param) {
+				values = append(values, ruleValue(ref, strings.Trim(value, "'")))
+			}
+			schema.Set("enum", values)
+		default:
+			if format, ok := validationFormats[name]; ok {
+				schema.Set("format", format)
+			} else if pattern, ok := validationPatterns[name]; ok {
+				schema.Set("pattern", pattern)
+			}
+		}
+	}
+}
+
+// setBound sets the lower or upper bound keyword matching the kind of ref. For numbers an
+// offset of 1 makes the bound exclusive, for lengths and counts it is added to the bound.
+func setBound(schema *jsonObject, ref *TypeRef, param string, lower bool, offset int) {
+	var keyword string
+	switch {
+	case ref.Kind == KindArray:
+		keyword = "maxItems"
+	case ref.Kind == KindMap:
+		keyword = "maxProperties"
+	case ref.Kind == KindPrimitive && ref.Name == "string" && !isTimeRef(ref):
+		keyword = "maxLength"
+	case ref.Kind == KindPrimitive && ref.Name == "number":
+		if _, err := strconv.ParseFloat(param, 64); err != nil {
+			return
+		}
+		switch {
+		case lower && offset > 0:
+			keyword = "exclusiveMinimum"
+		case lower:
+			keyword = "minimum"
+		case offset > 0:
+			keyword = "exclusiveMaximum"
+		default:
+			keyword = "maximum"
+		}
+		schema.Set(keyword, json.Number(param))
+		return
+	default:
+		return
+	}
+
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return
+	}
+	if lower {
+		keyword = "min" + strings.TrimPrefix(keyword, "max")
+		n += offset
+	} else {
+		n -= offset
+	}
+	if n < 0 {
+		return
+	}
+	schema.Set(keyword, n)
+}
+
+// ruleValue converts a validation rule parameter to a value of the field type
+func ruleValue(ref *TypeRef, param string) any {
+	if ref.Kind == KindPrimitive && ref.Name == "number" {
+		if _, err := strconv.ParseFloat(param, 64); err == nil {
+			return json.Number(param)
+		}
+	}
+	if ref.Kind == KindPrimitive && ref.Name == "boolean" {
+		if b, err := strconv.ParseBool(param); err == nil {
+			return b
+		}
+	}
+	return param
+}
@@ -0,0 +1,447 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MockMaxDepth is the nesting depth after which the mock factories stop following
+// references: nullable references become null, arrays and maps of referenced types empty,
+// and unions use a member that does not lead back to them
+const MockMaxDepth = 3
+
+// mockDate is the sample value of time.Time fields
+const mockDate = "2024-01-01T00:00:00Z"
+
+// mockFormatValues are the sample values of string formats
+var mockFormatValues = map[string]string{
+	"email":     "user@example.com",
+	"uri":       "https://example.com",
+	"uuid":      "00000000-0000-4000-8000-000000000000",
+	"ipv4":      "192.0.2.1",
+	"ipv6":      "2001:db8::1",
+	"ip":        "192.0.2.1",
+	"hostname":  "example.com",
+	"date-time": mockDate,
+	"date":      "2024-01-01",
+	"binary":    "",
+	"byte":      "",
+}
+
+// GenerateMocks writes mock factories for the types of the model, importing the types from
+// typesModule (e.g. "./types"). makeX(overrides?, depth?) returns a valid X with deterministic
+// sample values, respecting nullability and the validate and binding rules of the fields.
+func GenerateMocks(model *Model, w io.Writer, typesModule string) error {
+	return generateMocks(model, w, func(used []string) []tsImport {
+		return []tsImport{{Names: used, From: typesModule}}
+	})
+}
+
+// WriteMocks writes the mock factories of the model to the target file. The types are imported
+// from typesFile, or from opts.APITypesFile for the API types when it is set.
+func WriteMocks(model *Model, targetFile, typesFile string, opts Options) error {
+	target := RenderTarget{Path: targetFile, TypesFile: typesFile, Options: opts}
+	return RenderToFile(typeModuleRenderer(target, generateMocks), model, targetFile)
+}
+
+// generateMocks writes the mock factories, with the imports returned by imports for the
+// collected types
+func generateMocks(model *Model, w io.Writer, imports func(used []string) []tsImport) error {
+	m := &mockBuilder{
+		schemas: newSchemaBuilder(model.Types, "#/$defs/", false),
+		known:   make(map[string]bool),
+	}
+	var refs []*TypeRef
+	for _, t := range model.Types {
+		m.known[t.Name] = true
+		refs = append(refs, namedRef(t.Name))
+	}
+	m.rankTerminating(model.Types)
+
+	var b strings.Builder
+	writeModuleHeader(&b, model, refs, nil, imports)
+	fmt.Fprintf(&b, "/**\n * Nesting depth after which recursive references are null or empty\n */\nexport const MOCK_MAX_DEPTH = %d;\n", MockMaxDepth)
+
+	for _, t := range model.Types {
+		b.WriteString("\n")
+		factory := mockFactoryName(t.Name)
+		if !t.IsInterface {
+			// Type aliases have a single "value" field holding the aliased type
+			value := "null"
+			if len(t.Fields) > 0 {
+				value = m.fieldValue(t.Fields[0], t.Name)
+			}
+			fmt.Fprintf(&b, "/**\n * Creates a %s with a sample value, unless a value is given\n */\n", t.Name)
+			fmt.Fprintf(&b, "export function %s(value?: %s, %s): %s {\n", factory, t.Name, depthParam(value), t.Name)
+			fmt.Fprintf(&b, "  return value !== undefined ? value : %s;\n}\n", value)
+			continue
+		}
+
+		var fields strings.Builder
+		for _, field := range t.Fields {
+			fmt.Fprintf(&fields, "    %s: %s,\n", tsPropertyKey(field.Name), m.fieldValue(field, field.Name))
+		}
+		fmt.Fprintf(&b, "/**\n * Creates a %s with sample values, replaced by the given overrides\n */\n", t.Name)
+		fmt.Fprintf(&b, "export function %s(overrides: Partial<%s> = {}, %s): %s {\n", factory, t.Name, depthParam(fields.String()), t.Name)
+		b.WriteString("  return {\n")
+		b.WriteString(fields.String())
+		b.WriteString("    ...overrides,\n  };\n}\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// depthParam returns the depth parameter of a factory, prefixed with an underscore when
+// the factory body does not use it
+func depthParam(body string) string {
+	if strings.Contains(body, "depth") {
+		return "depth = 0"
+	}
+	return "_depth = 0"
+}
+
+// mockBuilder builds the sample values of fields
+type mockBuilder struct {
+	schemas *schemaBuilder
+	known   map[string]bool // Names of the collected types
+	// Ranks of the types whose factories terminate past MOCK_MAX_DEPTH: their references
+	// past the limit only lead to types of a lower rank
+	ranks map[string]int
+}
+
+// rankTerminating ranks the types whose factories terminate past MOCK_MAX_DEPTH, where
+// nullable references, arrays and maps stop and unions use their lowest ranked member.
+// Types that are never ranked only reach themselves, e.g. unions of recursive members.
+func (m *mockBuilder) rankTerminating(types []TypeScriptType) {
+	m.ranks = make(map[string]int)
+	for changed := true; changed; {
+		changed = false
+		for _, t := range types {
+			if _, ok := m.ranks[t.Name]; ok {
+				continue
+			}
+			terminates := true
+			for _, field := range t.Fields {
+				if _, ok := m.rank(field.TypeRef); !ok {
+					terminates = false
+					break
+				}
+			}
+			if terminates {
+				m.ranks[t.Name] = len(m.ranks) + 1
+				changed = true
+			}
+		}
+	}
+}
+
+// rank returns the highest rank of the types a TypeRef calls the factory of past
+// MOCK_MAX_DEPTH, and whether they all terminate
+func (m *mockBuilder) rank(ref *TypeRef) (int, bool) {
+	if ref == nil {
+		return 0, true
+	}
+	switch ref.Kind {
+	case KindNamed:
+		if !m.known[ref.Name] {
+			return 0, true
+		}
+		rank, ok := m.ranks[ref.Name]
+		return rank, ok
+	case KindNullable, KindArray, KindMap:
+		// References stop at the maximum depth
+		return 0, true
+	case KindComposite:
+		rank, ok := m.rank(namedRef(ref.Name))
+		for _, override := range ref.Overrides {
+			overrideRank, overrideOK := m.rank(override.Type)
+			rank, ok = max(rank, overrideRank), ok && overrideOK
+		}
+		return rank, ok
+	case KindUnion:
+		if _, member, ok := m.fallbackMember(ref); ok {
+			return m.rank(member)
+		}
+		return 0, len(ref.Members) == 0
+	}
+	return 0, true
+}
+
+// fallbackMember returns the member of a union used past MOCK_MAX_DEPTH: the member of the
+// lowest rank, which does not lead back to the union
+func (m *mockBuilder) fallbackMember(ref *TypeRef) (int, *TypeRef, bool) {
+	index, lowest := -1, 0
+	for i, member := range ref.Members {
+		if rank, ok := m.rank(member); ok && (index < 0 || rank < lowest) {
+			index, lowest = i, rank
+		}
+	}
+	if index < 0 {
+		return -1, nil, false
+	}
+	return index, ref.Members[index], true
+}
+
+// fieldValue returns the sample value expression of a field. The constraints of the field
+// are read from its JSON Schema, so they follow the same validate and binding rules.
+func (m *mockBuilder) fieldValue(field TypeScriptField, label string) string {
+	return m.value(field.TypeRef, m.schemas.fieldSchema(field), label)
+}
+
+// value returns the sample value expression of a TypeRef constrained by its schema
+func (m *mockBuilder) value(ref *TypeRef, schema *jsonObject, label string) string {
+	if ref == nil {
+		return "null"
+	}
+	switch ref.Kind {
+	case KindNamed:
+		if !m.known[ref.Name] {
+			// Placeholders of types that were not collected accept any value
+			return "null"
+		}
+		value := mockFactoryName(ref.Name) + "(undefined, depth + 1)"
+		if _, ok := m.ranks[ref.Name]; !ok {
+			// The type only leads back to itself and has no sample value past the limit
+			return "depth < MOCK_MAX_DEPTH ? " + value + " : null as never"
+		}
+		return value
+	case KindNullable:
+		// Constraints of nullable references apply to the referenced schema
+		elemSchema := schema
+		if anyOf, ok := schema.Get("anyOf"); ok {
+			elemSchema = anyOf.([]any)[0].(*jsonObject)
+		}
+		value := m.value(ref.Elem, elemSchema, label)
+		if m.references(ref.Elem) {
+			return "depth < MOCK_MAX_DEPTH ? " + value + " : null"
+		}
+		return value
+	case KindArray:
+		count := 1
+		if n, ok := schemaInt(schema, "minItems"); ok && n > count {
+			count = n
+		}
+		if n, ok := schemaInt(schema, "maxItems"); ok && n < count {
+			count = n
+		}
+		itemSchema, _ := schema.Get("items")
+		item := m.value(ref.Elem, schemaObject(itemSchema), label)
+		items := make([]string, count)
+		for i := range items {
+			items[i] = item
+		}
+		value := "[" + strings.Join(items, ", ") + "]"
+		if count > 0 && m.references(ref.Elem) {
+			return "depth < MOCK_MAX_DEPTH ? " + value + " : []"
+		}
+		return value
+	case KindMap:
+		if n, ok := schemaInt(schema, "maxProperties"); ok && n == 0 {
+			return "{}"
+		}
+		key := "key"
+		if ref.Key != nil && ref.Key.Kind == KindPrimitive && ref.Key.Name == "number" {
+			key = "1"
+		}
+		elemSchema, _ := schema.Get("additionalProperties")
+		value := "{ " + strconv.Quote(key) + ": " + m.value(ref.Elem, schemaObject(elemSchema), label) + " }"
+		if m.references(ref.Elem) {
+			return "depth < MOCK_MAX_DEPTH ? " + value + " : {}"
+		}
+		return value
+	case KindComposite:
+		// The overridden fields replace the fields of a sample base value
+		fields := []string{}
+		if m.known[ref.Name] {
+			fields = append(fields, "..."+m.value(namedRef(ref.Name), newJSONObject(), label))
+		}
+		for _, override := range ref.Overrides {
+			overrideSchema := m.schemas.refSchema(override.Type)
+			fields = append(fields, tsPropertyKey(override.Name)+": "+m.value(override.Type, overrideSchema, override.Name))
+		}
+		return "{ " + strings.Join(fields, ", ") + " }"
+	case KindUnion:
+		// Unions use their first member, and past the limit a member that does not lead
+		// back to the union
+		if len(ref.Members) == 0 {
+			return "null"
+		}
+		value := m.value(ref.Members[0], newJSONObject(), label)
+		index, member, ok := m.fallbackMember(ref)
+		if !ok {
+			return "depth < MOCK_MAX_DEPTH ? " + value + " : null as never"
+		}
+		if index == 0 {
+			return value
+		}
+		return "depth < MOCK_MAX_DEPTH ? " + value + " : " + m.value(member, newJSONObject(), label)
+	}
+	return primitiveMock(ref, schema, label)
+}
+
+// references reports whether a TypeRef references a collected type, which may lead back
+// to the type being created
+func (m *mockBuilder) references(ref *TypeRef) bool {
+	for _, name := range ref.NamedTypes() {
+		if m.known[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// primitiveMock returns the sample value of a primitive TypeRef constrained by its schema:
+// the first enum or const value, a sample of its format, or a value within its bounds
+func primitiveMock(ref *TypeRef, schema *jsonObject, label string) string {
+	if isTimeRef(ref) {
+		if ref.Name == "Date" {
+			return "new Date(" + strconv.Quote(mockDate) + ")"
+		}
+		return strconv.Quote(mockDate)
+	}
+	if value, ok := schema.Get("const"); ok {
+		return jsonLiteral(value)
+	}
+	if values, ok := schema.Get("enum"); ok {
+		if list, ok := values.([]any); ok && len(list) > 0 {
+			return jsonLiteral(list[0])
+		}
+	}
+
+	// Unions use their first member: "a" | "b" -> "a", number | string -> 1
+	name, _, _ := strings.Cut(ref.Name, " | ")
+	switch name {
+	case "string":
+		return strconv.Quote(stringMock(schema, label))
+	case "number":
+		return numberMock(schema, ref.Format)
+	case "boolean":
+		return "true"
+	case "null", "any", "unknown":
+		return "null"
+	}
+	if strings.HasPrefix(name, "\"") || name == "true" || name == "false" || (name != "" && unicode.IsDigit(rune(name[0]))) {
+		return name
+	}
+	return "null"
+}
+
+// stringMock returns a sample string of the schema's format or pattern, derived from label
+// and adjusted to the length bounds
+func stringMock(schema *jsonObject, label string) string {
+	if format, ok := schema.Get("format"); ok {
+		if value, ok := mockFormatValues[format.(string)]; ok {
+			return value
+		}
+	}
+	if _, ok := schema.Get("contentEncoding"); ok {
+		return ""
+	}
+
+	value := label
+	padding := "x"
+	if pattern, ok := schema.Get("pattern"); ok {
+		switch pattern {
+		case validationPatterns["alpha"]:
+			value = strings.Map(func(r rune) rune {
+				if r < unicode.MaxASCII && unicode.IsLetter(r) {
+					return r
+				}
+				return -1
+			}, label)
+		case validationPatterns["alphanum"]:
+			value = strings.Map(func(r rune) rune {
+				if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+					return r
+				}
+				return -1
+			}, label)
+		case validationPatterns["numeric"], validationPatterns["number"]:
+			value, padding = "1", "0"
+		}
+	}
+
+	if n, ok := schemaInt(schema, "minLength"); ok && len(value) < n {
+		value += strings.Repeat(padding, n-len(value))
+	}
+	if n, ok := schemaInt(schema, "maxLength"); ok && len(value) > n {
+		value = value[:n]
+	}
+	return value
+}
+
+// numberMock returns a sample number within the bounds of the schema, 1 by default
+func numberMock(schema *jsonObject, format string) string {
+	value := 1.0
+	integer := format == "int32" || format == "int64"
+	step := 1.0
+	if !integer {
+		step = 0.5
+	}
+
+	if min, ok := schemaFloat(schema, "minimum"); ok && value < min {
+		value = min
+	}
+	if min, ok := schemaFloat(schema, "exclusiveMinimum"); ok && value <= min {
+		value = min + step
+	}
+	if max, ok := schemaFloat(schema, "maximum"); ok && value > max {
+		value = max
+	}
+	if max, ok := schemaFloat(schema, "exclusiveMaximum"); ok && value >= max {
+		value = max - step
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// schemaObject returns a schema found in another schema, or an empty schema
+func schemaObject(value any) *jsonObject {
+	if schema, ok := value.(*jsonObject); ok {
+		return schema
+	}
+	return newJSONObject()
+}
+
+// schemaInt returns an integer keyword of a schema
+func schemaInt(schema *jsonObject, keyword string) (int, bool) {
+	value, ok := schema.Get(keyword)
+	if !ok {
+		return 0, false
+	}
+	n, ok := value.(int)
+	return n, ok
+}
+
+// schemaFloat returns a numeric keyword of a schema
+func schemaFloat(schema *jsonObject, keyword string) (float64, bool) {
+	value, ok := schema.Get(keyword)
+	if !ok {
+		return 0, false
+	}
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	f, err := number.Float64()
+	return f, err == nil
+}
+
+// jsonLiteral returns the TypeScript literal of a JSON value
+func jsonLiteral(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "null"
+	}
+	return string(data)
+}
+
+// mockFactoryName returns the name of the mock factory of a type
+// e.g. UserResponse -> makeUserResponse
+func mockFactoryName(typeName string) string {
+	return "make" + upperFirst(typeName)
+}
@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Model represents the types and operations collected from a set of source directories.
+// It is shared by the TypeScript, JSON Schema, OpenAPI and other outputs.
+type Model struct {
+	Types      []TypeScriptType `json:"types"`
+	Operations []Operation      `json:"operations"`
+	Info       APIInfo          `json:"info"`
+}
+
+// CollectModel collects the type definitions, endpoint information and operations from the
+// source directories using the given options
+func CollectModel(sourceDirs []string, opts Options) (*Model, error) {
+	return collectModel(context.Background(), sourceDirs, opts)
+}
+
+// MergeModels returns the types and operations of the models in one model. A type declared
+// in several models, or an operation registered with the same method and path, is kept from
+// the first model declaring it. The API information is the first one with a title.
+func MergeModels(models ...*Model) *Model {
+	merged := &Model{Types: []TypeScriptType{}, Operations: []Operation{}}
+	types := make(map[string]bool)
+	operations := make(map[string]bool)
+	for _, model := range models {
+		for _, t := range model.Types {
+			if !types[t.Name] {
+				types[t.Name] = true
+				merged.Types = append(merged.Types, t)
+			}
+		}
+		for _, op := range model.Operations {
+			if key := op.Method + " " + op.Path; !operations[key] {
+				operations[key] = true
+				merged.Operations = append(merged.Operations, op)
+			}
+		}
+		if merged.Info.Title == "" && model.Info.Title != "" {
+			merged.Info = model.Info
+		}
+	}
+	return merged
+}
+
+// SaveModel writes the model as the JSON intermediate representation, versioned with
+// IRVersion, so it can be compared, rendered or processed by other tools later without
+// parsing the Go sources again
+func SaveModel(model *Model, w io.Writer) error {
+	data, err := json.MarshalIndent(irDocument{Version: IRVersion, Model: model}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding model: %v", err)
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// LoadModel reads a model written by SaveModel. Intermediate representations of a newer
+// version than IRVersion are rejected.
+func LoadModel(r io.Reader) (*Model, error) {
+	doc := irDocument{Model: &Model{}}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("error decoding model: %v", err)
+	}
+	if doc.Version == 0 {
+		return nil, fmt.Errorf("error decoding model: missing IR version")
+	}
+	if doc.Version > IRVersion {
+		return nil, fmt.Errorf("error decoding model: unsupported IR version %d (supported: %d)", doc.Version, IRVersion)
+	}
+	return doc.Model, nil
+}
+
+// WriteIR writes the intermediate representation of the model to the target file
+func WriteIR(model *Model, targetFile string) error {
+	file, err := os.Create(targetFile)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer file.Close()
+
+	return SaveModel(model, file)
+}
+
+// ReadIR reads a model from an intermediate representation file written by WriteIR
+func ReadIR(sourceFile string) (*Model, error) {
+	file, err := os.Open(sourceFile)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %v", err)
+	}
+	defer file.Close()
+
+	return LoadModel(file)
+}
+
+// WriteTypeScript writes the TypeScript definitions of the model to the target file
+// (and to opts.APITypesFile when set)
+func WriteTypeScript(model *Model, targetFile string, opts Options) error {
+	return RenderToFile(TypeScriptRenderer{Options: opts}, model, targetFile)
+}
+
+// WriteJSONSchema writes the JSON Schema document of the model to the target file
+func WriteJSONSchema(model *Model, targetFile string) error {
+	file, err := os.Create(targetFile)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer file.Close()
+
+	return GenerateJSONSchema(model, file)
+}
+
+// WriteOpenAPI writes the OpenAPI document of the model to the target file, encoded as JSON
+// when the file has a .json extension and as YAML otherwise
+func WriteOpenAPI(model *Model, targetFile string) error {
+	file, err := os.Create(targetFile)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(targetFile), ".json") {
+		return GenerateOpenAPIJSON(model, file)
+	}
+	return GenerateOpenAPIYAML(model, file)
+}
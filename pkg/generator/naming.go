@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy controls how Go field names are converted to property names
+type NamingStrategy string
+
+// Property naming strategies
+const (
+	NamingPreserve   NamingStrategy = "preserve"   // Keep the Go name (UserID)
+	NamingCamelCase  NamingStrategy = "camelCase"  // userId
+	NamingSnakeCase  NamingStrategy = "snake_case" // user_id
+	NamingPascalCase NamingStrategy = "PascalCase" // UserId
+)
+
+// ParseNamingStrategy parses the name of a property naming strategy
+func ParseNamingStrategy(s string) (NamingStrategy, error) {
+	switch strategy := NamingStrategy(strings.TrimSpace(s)); strategy {
+	case "", NamingPreserve:
+		return NamingPreserve, nil
+	case NamingCamelCase, NamingSnakeCase, NamingPascalCase:
+		return strategy, nil
+	}
+	return "", fmt.Errorf("unknown naming strategy %q (expected preserve, camelCase, snake_case or PascalCase)", s)
+}
+
+// Apply converts a name with the strategy
+func (s NamingStrategy) Apply(name string) string {
+	switch s {
+	case NamingCamelCase:
+		return toCamelCase(name)
+	case NamingSnakeCase:
+		return toSnakeCase(name)
+	case NamingPascalCase:
+		return toPascalCase(name)
+	}
+	return name
+}
+
+// propertyName returns the property name of a field. Untagged fields, and tagged fields
+// when opts.PropertyNamingAll is set, are renamed with opts.PropertyNamingFunc or
+// opts.PropertyNaming.
+func propertyName(name string, tagged bool, opts Options) string {
+	if tagged && !opts.PropertyNamingAll {
+		return name
+	}
+	if opts.PropertyNamingFunc != nil {
+		return opts.PropertyNamingFunc(name)
+	}
+	return opts.PropertyNaming.Apply(name)
+}
+
+// splitWords splits a name into words at underscores, hyphens, spaces and case changes,
+// keeping acronyms together
+// e.g. UserID -> [User ID], HTTPServer -> [HTTP Server], created_at -> [created at]
+func splitWords(name string) []string {
+	var words []string
+	for _, part := range identifierWords(name) {
+		runes := []rune(part)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			prev, cur := runes[i-1], runes[i]
+			lowerToUpper := (unicode.IsLower(prev) || unicode.IsDigit(prev)) && unicode.IsUpper(cur)
+			// The last capital of an acronym starts the next word: HTTPServer -> HTTP Server
+			acronymEnd := unicode.IsUpper(prev) && unicode.IsUpper(cur) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if lowerToUpper || acronymEnd {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// toCamelCase converts a snake_case or PascalCase string to camelCase
+// e.g. UserID -> userId, created_at -> createdAt
+func toCamelCase(s string) string {
+	return lowerFirst(toPascalCase(s))
+}
+
+// toPascalCase converts a string to PascalCase
+// e.g. user_id -> UserId, HTTPServer -> HttpServer
+func toPascalCase(s string) string {
+	var result strings.Builder
+	for _, word := range splitWords(s) {
+		result.WriteString(upperFirst(strings.ToLower(word)))
+	}
+	return result.String()
+}
+
+// toSnakeCase converts a string to snake_case
+// e.g. UserID -> user_id, HTTPServer -> http_server
+func toSnakeCase(s string) string {
+	words := splitWords(s)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, "_")
+}
@@ -0,0 +1,361 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// OpenAPIVersion is the version of the generated OpenAPI documents
+const OpenAPIVersion = "3.1.0"
+
+// GenerateOpenAPIJSON writes the OpenAPI document of the model encoded as JSON
+func GenerateOpenAPIJSON(model *Model, w io.Writer) error {
+	data, err := json.MarshalIndent(openAPIDocument(model), "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding OpenAPI document: %v", err)
+	}
+	data = append(data, '\n')
+	_, err = w.Write(data)
+	return err
+}
+
+// GenerateOpenAPIYAML writes the OpenAPI document of the model encoded as YAML
+func GenerateOpenAPIYAML(model *Model, w io.Writer) error {
+	_, err := w.Write(encodeYAML(openAPIDocument(model)))
+	return err
+}
+
+// pathParamRegex matches the {param} placeholders of a path
+var pathParamRegex = regexp.MustCompile(`\{([^}]+)\}`)
+
+// openAPIDocument builds an OpenAPI 3.1 document from the operations of the model. The
+// collected types become component schemas referenced from parameters, request bodies and
+// responses.
+func openAPIDocument(model *Model) *jsonObject {
+	b := newSchemaBuilder(model.Types, "#/components/schemas/", true)
+
+	info := newJSONObject()
+	info.Set("title", stringOr(model.Info.Title, "API"))
+	info.Set("version", stringOr(model.Info.Version, "1.0.0"))
+	if model.Info.Description != "" {
+		info.Set("description", model.Info.Description)
+	}
+
+	doc := newJSONObject()
+	doc.Set("openapi", OpenAPIVersion)
+	doc.Set("info", info)
+	if model.Info.BasePath != "" {
+		doc.Set("servers", []any{newJSONObject().Set("url", model.Info.BasePath)})
+	}
+
+	paths := newJSONObject()
+	for _, op := range model.Operations {
+		item, ok := paths.Get(op.Path)
+		if !ok {
+			item = newJSONObject()
+			paths.Set(op.Path, item)
+		}
+		item.(*jsonObject).Set(op.Method, b.operation(op))
+	}
+	doc.Set("paths", paths)
+
+	components := newJSONObject().Set("schemas", b.typeSchemas())
+	if len(model.Info.SecuritySchemes) > 0 {
+		schemes := newJSONObject()
+		for _, scheme := range model.Info.SecuritySchemes {
+			schemes.Set(scheme.Name, securitySchemeObject(scheme))
+		}
+		components.Set("securitySchemes", schemes)
+	}
+	doc.Set("components", components)
+	return doc
+}
+
+// operation returns the OpenAPI operation object of an operation
+func (b *schemaBuilder) operation(op Operation) *jsonObject {
+	result := newJSONObject()
+	if len(op.Tags) > 0 {
+		result.Set("tags", op.Tags)
+	}
+	if op.Summary != "" {
+		result.Set("summary", op.Summary)
+	}
+	if op.Description != "" {
+		result.Set("description", op.Description)
+	}
+	if op.ID != "" {
+		result.Set("operationId", op.ID)
+	}
+
+	var parameters []any
+	var body *Parameter
+	var formData []Parameter
+	declared := make(map[string]bool)
+	for i, param := range op.Parameters {
+		switch param.In {
+		case "body":
+			body = &op.Parameters[i]
+		case "formData":
+			formData = append(formData, param)
+		case "query":
+			// Struct query parameters are expanded into their fields, as swag does
+			if fields, ok := b.structFields(param.Type); ok {
+				for _, field := range fields {
+					parameters = append(parameters, b.parameter(Parameter{
+						Name:        field.Name,
+						In:          "query",
+						Type:        field.TypeRef,
+						Required:    !field.Optional,
+						Description: strings.TrimSpace(field.Comment),
+					}))
+				}
+				continue
+			}
+			parameters = append(parameters, b.parameter(param))
+		default:
+			declared[param.Name] = param.In == "path"
+			parameters = append(parameters, b.parameter(param))
+		}
+	}
+
+	// Path parameters must be declared, so add the ones without a @Param annotation
+	for _, match := range pathParamRegex.FindAllStringSubmatch(op.Path, -1) {
+		if !declared[match[1]] {
+			parameters = append(parameters, b.parameter(Parameter{Name: match[1], In: "path", Type: primitiveRef("string")}))
+		}
+	}
+	if len(parameters) > 0 {
+		result.Set("parameters", parameters)
+	}
+
+	if body != nil {
+		requestBody := newJSONObject()
+		if body.Description != "" {
+			requestBody.Set("description", body.Description)
+		}
+		if body.Required {
+			requestBody.Set("required", true)
+		}
+		requestBody.Set("content", mediaContent(op.Accept, b.refSchema(body.Type)))
+		result.Set("requestBody", requestBody)
+	} else if len(formData) > 0 {
+		result.Set("requestBody", b.formRequestBody(formData, op.Accept))
+	}
+
+	if len(op.Responses) > 0 {
+		responses := newJSONObject()
+		for _, resp := range op.Responses {
+			response := newJSONObject()
+			response.Set("description", responseDescription(resp))
+			if len(resp.Headers) > 0 {
+				headers := newJSONObject()
+				for _, header := range resp.Headers {
+					headerObject := newJSONObject()
+					if header.Description != "" {
+						headerObject.Set("description", header.Description)
+					}
+					headerObject.Set("schema", b.refSchema(header.Type))
+					headers.Set(header.Name, headerObject)
+				}
+				response.Set("headers", headers)
+			}
+			if resp.Type != nil {
+				response.Set("content", mediaContent(op.Produce, b.refSchema(resp.Type)))
+			}
+			responses.Set(resp.Status, response)
+		}
+		result.Set("responses", responses)
+	}
+
+	if op.Deprecated {
+		result.Set("deprecated", true)
+	}
+	if len(op.Security) > 0 {
+		var security []any
+		for _, requirement := range op.Security {
+			requirementObject := newJSONObject()
+			for _, scheme := range requirement {
+				requirementObject.Set(scheme.Name, append([]string{}, scheme.Scopes...))
+			}
+			security = append(security, requirementObject)
+		}
+		result.Set("security", security)
+	}
+	return result
+}
+
+// securitySchemeObject returns the OpenAPI security scheme object of a security definition
+func securitySchemeObject(scheme SecurityDefinition) *jsonObject {
+	result := newJSONObject()
+	switch scheme.Type {
+	case "apikey":
+		result.Set("type", "apiKey")
+		result.Set("in", stringOr(scheme.In, "header"))
+		result.Set("name", scheme.ParamName)
+	case "basic":
+		result.Set("type", "http")
+		result.Set("scheme", "basic")
+	case "oauth2":
+		result.Set("type", "oauth2")
+		flow := newJSONObject()
+		if scheme.AuthorizationURL != "" {
+			flow.Set("authorizationUrl", scheme.AuthorizationURL)
+		}
+		if scheme.TokenURL != "" {
+			flow.Set("tokenUrl", scheme.TokenURL)
+		}
+		scopes := newJSONObject()
+		for _, scope := range scheme.Scopes {
+			scopes.Set(scope.Name, scope.Description)
+		}
+		flow.Set("scopes", scopes)
+		result.Set("flows", newJSONObject().Set(oauth2FlowNames[scheme.Flow], flow))
+	default:
+		result.Set("type", scheme.Type)
+	}
+	if scheme.Description != "" {
+		result.Set("description", scheme.Description)
+	}
+	return result
+}
+
+// oauth2FlowNames maps the OAuth2 flows of swag to OpenAPI 3 flow names
+var oauth2FlowNames = map[string]string{
+	"application": "clientCredentials",
+	"password":    "password",
+	"implicit":    "implicit",
+	"accessCode":  "authorizationCode",
+}
+
+// parameter returns the OpenAPI parameter object of a path, query, header or cookie parameter
+func (b *schemaBuilder) parameter(param Parameter) *jsonObject {
+	result := newJSONObject()
+	result.Set("name", param.Name)
+	result.Set("in", param.In)
+	if param.Description != "" {
+		result.Set("description", param.Description)
+	}
+	// Path parameters are always required
+	if param.Required || param.In == "path" {
+		result.Set("required", true)
+	}
+	result.Set("schema", b.parameterSchema(param))
+	return result
+}
+
+// formRequestBody returns the request body of formData parameters, using multipart/form-data
+// when a file is uploaded and no MIME type is declared with @Accept
+func (b *schemaBuilder) formRequestBody(params []Parameter, accept []string) *jsonObject {
+	mediaType := "application/x-www-form-urlencoded"
+	properties := newJSONObject()
+	var required []string
+	for _, param := range params {
+		schema := b.parameterSchema(param)
+		if param.Description != "" {
+			schema = mergeSchema(newJSONObject().Set("description", param.Description), schema)
+		}
+		properties.Set(param.Name, schema)
+		if param.Required {
+			required = append(required, param.Name)
+		}
+		if param.Type != nil && param.Type.Format == "binary" {
+			mediaType = "multipart/form-data"
+		}
+	}
+
+	schema := newJSONObject().Set("type", "object").Set("properties", properties)
+	if len(required) > 0 {
+		schema.Set("required", required)
+	}
+	// JSON is the default MIME type of @Accept, which does not apply to forms
+	if len(accept) > 0 && !(len(accept) == 1 && accept[0] == "application/json") {
+		return newJSONObject().Set("content", mediaContent(accept, schema))
+	}
+	return newJSONObject().Set("content", mediaContent([]string{mediaType}, schema))
+}
+
+// parameterSchema returns the schema of a parameter, including the constraints declared with
+// attributes such as enums(a,b), default(x) or minimum(1)
+func (b *schemaBuilder) parameterSchema(param Parameter) *jsonObject {
+	schema := b.refSchema(param.Type)
+	for _, name := range []string{"format", "minimum", "maximum", "minlength", "maxlength", "enums", "default", "example"} {
+		value, ok := param.Attributes[name]
+		if !ok {
+			continue
+		}
+		switch name {
+		case "format":
+			schema.Set("format", value)
+		case "minimum", "maximum":
+			if _, err := strconv.ParseFloat(value, 64); err == nil {
+				schema.Set(name, json.Number(value))
+			}
+		case "minlength", "maxlength":
+			if n, err := strconv.Atoi(value); err == nil {
+				schema.Set(strings.TrimSuffix(name, "length")+"Length", n)
+			}
+		case "enums":
+			var values []any
+			for _, v := range strings.Split(value, ",") {
+				values = append(values, ruleValue(param.Type, strings.TrimSpace(v)))
+			}
+			schema.Set("enum", values)
+		case "default":
+			schema.Set("default", ruleValue(param.Type, value))
+		case "example":
+			schema.Set("examples", []any{ruleValue(param.Type, value)})
+		}
+	}
+	return schema
+}
+
+// structFields returns the fields of a collected struct type referenced by ref
+func (b *schemaBuilder) structFields(ref *TypeRef) ([]TypeScriptField, bool) {
+	if ref == nil || ref.Kind != KindNamed {
+		return nil, false
+	}
+	for _, t := range b.types {
+		if t.Name == ref.Name && t.IsInterface {
+			return t.Fields, true
+		}
+	}
+	return nil, false
+}
+
+// mediaContent returns a content object with the schema for each media type, defaulting
+// to application/json
+func mediaContent(mediaTypes []string, schema *jsonObject) *jsonObject {
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{"application/json"}
+	}
+	content := newJSONObject()
+	for _, mediaType := range mediaTypes {
+		content.Set(mediaType, newJSONObject().Set("schema", schema))
+	}
+	return content
+}
+
+// responseDescription returns the description of a response, which is required by OpenAPI,
+// falling back to the status text of its code
+func responseDescription(resp Response) string {
+	if resp.Description != "" {
+		return resp.Description
+	}
+	if code, err := strconv.Atoi(resp.Status); err == nil && http.StatusText(code) != "" {
+		return http.StatusText(code)
+	}
+	return "Response"
+}
+
+// stringOr returns s, or fallback when s is empty
+func stringOr(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
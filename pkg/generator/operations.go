@@ -0,0 +1,519 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Operation represents an API operation declared with swag annotations
+type Operation struct {
+	Method      string                `json:"method"`                // HTTP method in lower case (e.g. get)
+	Path        string                `json:"path"`                  // Path with {param} placeholders
+	ID          string                `json:"id,omitempty"`          // @ID
+	Summary     string                `json:"summary,omitempty"`     // @Summary
+	Description string                `json:"description,omitempty"` // @Description
+	Tags        []string              `json:"tags,omitempty"`        // @Tags
+	Accept      []string              `json:"accept,omitempty"`      // MIME types of request bodies declared with @Accept
+	Produce     []string              `json:"produce,omitempty"`     // MIME types of responses declared with @Produce
+	Parameters  []Parameter           `json:"parameters,omitempty"`  // @Param
+	Responses   []Response            `json:"responses,omitempty"`   // @Success and @Failure, with the headers declared with @Header
+	Security    []SecurityRequirement `json:"security,omitempty"`    // @Security, any one of the requirements must be satisfied
+	Deprecated  bool                  `json:"deprecated,omitempty"`  // @Deprecated
+	Handler     string                `json:"handler,omitempty"`     // Name of the annotated function, if any
+}
+
+// Parameter represents an operation parameter declared with @Param
+type Parameter struct {
+	Name        string            `json:"name"`
+	In          string            `json:"in"`             // path, query, header, cookie, body or formData
+	Type        *TypeRef          `json:"type,omitempty"` // Parameter type
+	Required    bool              `json:"required,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"` // Attributes such as enums, default, minimum or format
+}
+
+// Response represents an operation response declared with @Success or @Failure
+type Response struct {
+	Status      string   `json:"status"`         // Status code or "default"
+	Type        *TypeRef `json:"type,omitempty"` // Response body type, nil for responses without a body
+	Description string   `json:"description,omitempty"`
+	Failure     bool     `json:"failure,omitempty"` // Whether the response was declared with @Failure
+	Headers     []Header `json:"headers,omitempty"` // Response headers declared with @Header
+}
+
+// Header represents a response header declared with @Header
+type Header struct {
+	Name        string   `json:"name"`
+	Type        *TypeRef `json:"type,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// SecurityRequirement lists the security schemes that must all be satisfied by a request
+type SecurityRequirement []SecurityScheme
+
+// SecurityScheme references a security scheme with the scopes required by an operation
+type SecurityScheme struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// SecurityDefinition represents a security scheme declared with @securityDefinitions
+type SecurityDefinition struct {
+	Name             string  `json:"name"`
+	Type             string  `json:"type"`                // apikey, basic or oauth2
+	Flow             string  `json:"flow,omitempty"`      // OAuth2 flow: application, password, implicit or accessCode
+	In               string  `json:"in,omitempty"`        // Location of the API key: header, query or cookie
+	ParamName        string  `json:"paramName,omitempty"` // Name of the API key header, query parameter or cookie
+	Description      string  `json:"description,omitempty"`
+	TokenURL         string  `json:"tokenUrl,omitempty"`
+	AuthorizationURL string  `json:"authorizationUrl,omitempty"`
+	Scopes           []Scope `json:"scopes,omitempty"`
+}
+
+// Scope represents an OAuth2 scope declared with @scope
+type Scope struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// APIInfo represents the general API information declared with @title, @version,
+// @description, @BasePath and @securityDefinitions
+type APIInfo struct {
+	Title           string               `json:"title,omitempty"`
+	Version         string               `json:"version,omitempty"`
+	Description     string               `json:"description,omitempty"`
+	BasePath        string               `json:"basePath,omitempty"`
+	SecuritySchemes []SecurityDefinition `json:"securitySchemes,omitempty"`
+}
+
+var (
+	// @Param name in type required "description" attributes
+	paramLineRegex = regexp.MustCompile(`^(\S+)\s+(\S+)\s+(\S+)\s+(\S+)(?:\s+"((?:[^"\\]|\\.)*)")?(.*)$`)
+	// @Success status {kind} type "description"
+	responseLineRegex = regexp.MustCompile(`^(\S+)(?:\s+\{(\w+)\})?(?:\s+([^\s"]+))?(?:\s+"((?:[^"\\]|\\.)*)")?`)
+	// Parameter attributes such as enums(a,b) or minimum(1)
+	paramAttributeRegex = regexp.MustCompile(`(\w+)\(([^)]*)\)`)
+	// General API information annotations, which are only read from the comments declaring them
+	generalInfoRegex = regexp.MustCompile(`(?mi)^\s*@(title|version|basepath|securitydefinitions\.\S+)\s`)
+	// Security schemes of a @Security requirement such as OAuth2[read, write]
+	securitySchemeRegex = regexp.MustCompile(`(\w+)(?:\[([^\]]*)\])?`)
+)
+
+// CollectOperations collects the operations and general API information declared with swag
+// annotations in the source directory
+func CollectOperations(sourceDir string) ([]Operation, APIInfo, error) {
+	return collectOperations(context.Background(), sourceDir)
+}
+
+// collectOperations collects the operations of the source directory, stopping when the
+// context is cancelled
+func collectOperations(ctx context.Context, sourceDir string) ([]Operation, APIInfo, error) {
+	var operations []Operation
+	var info APIInfo
+
+	err := walkContext(ctx, sourceDir, func(path string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Process only Go files
+		if fileInfo.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		fset := token.NewFileSet()
+		node, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			fmt.Printf("Error parsing file %s: %v\n", path, err)
+			return nil
+		}
+
+		// Map doc comments to the functions they document
+		handlers := make(map[*ast.CommentGroup]string)
+		for _, decl := range node.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Doc != nil {
+				handlers[funcDecl.Doc] = funcDecl.Name.Name
+			}
+		}
+
+		// Comment groups include doc comments, so every group is parsed once
+		for _, commentGroup := range node.Comments {
+			comment := commentGroup.Text()
+			if routerRegex.MatchString(comment) {
+				operations = append(operations, parseOperations(comment, handlers[commentGroup])...)
+			} else if generalInfoRegex.MatchString(comment) {
+				parseAPIInfo(comment, &info)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, info, fmt.Errorf("error walking directory for operations: %w", err)
+	}
+
+	return operations, info, nil
+}
+
+// parseOperations parses the swag annotations of a comment into one operation per @Router
+func parseOperations(comment, handler string) []Operation {
+	var op Operation
+	var routes [][2]string
+	var headers []string
+	op.Handler = handler
+
+	for _, line := range strings.Split(comment, "\n") {
+		annotation, rest := splitAnnotation(line)
+		switch annotation {
+		case "@router":
+			if match := routerRegex.FindStringSubmatch(line); match != nil {
+				for _, method := range strings.Split(match[2], ",") {
+					routes = append(routes, [2]string{strings.ToLower(strings.TrimSpace(method)), normalizePath(match[1])})
+				}
+			}
+		case "@id":
+			op.ID = rest
+		case "@summary":
+			op.Summary = rest
+		case "@description":
+			// Repeated @Description lines form a multi-line description
+			if op.Description != "" {
+				op.Description += "\n"
+			}
+			op.Description += rest
+		case "@tags":
+			op.Tags = append(op.Tags, splitList(rest)...)
+		case "@param":
+			if param, ok := parseParameter(rest); ok {
+				op.Parameters = append(op.Parameters, param)
+			}
+		case "@success", "@failure":
+			responses := parseResponses(rest)
+			for i := range responses {
+				responses[i].Failure = annotation == "@failure"
+			}
+			op.Responses = append(op.Responses, responses...)
+		case "@header":
+			// Headers apply to responses that may be declared after them
+			headers = append(headers, rest)
+		case "@accept":
+			op.Accept = append(op.Accept, mimeTypes(rest)...)
+		case "@produce":
+			op.Produce = append(op.Produce, mimeTypes(rest)...)
+		case "@security":
+			// Alternatives are separated by ||, schemes required together by &&
+			for _, alternative := range strings.Split(rest, "||") {
+				var requirement SecurityRequirement
+				for _, scheme := range strings.Split(alternative, "&&") {
+					if match := securitySchemeRegex.FindStringSubmatch(scheme); match != nil {
+						requirement = append(requirement, SecurityScheme{Name: match[1], Scopes: splitList(match[2])})
+					}
+				}
+				if len(requirement) > 0 {
+					op.Security = append(op.Security, requirement)
+				}
+			}
+		case "@deprecated":
+			op.Deprecated = true
+		}
+	}
+
+	for _, header := range headers {
+		addResponseHeader(op.Responses, header)
+	}
+
+	operations := make([]Operation, 0, len(routes))
+	for _, route := range routes {
+		routeOp := op
+		routeOp.Method, routeOp.Path = route[0], route[1]
+		operations = append(operations, routeOp)
+	}
+	return operations
+}
+
+// addResponseHeader parses the arguments of a @Header annotation and adds the header to the
+// responses with the listed status codes, or to all responses for "all"
+// e.g. 200,400 {string} X-Request-ID "Request ID"
+func addResponseHeader(responses []Response, s string) {
+	match := responseLineRegex.FindStringSubmatch(s)
+	if match == nil || match[3] == "" {
+		return
+	}
+	header := Header{
+		Name:        match[3],
+		Type:        swagTypeRef(match[2]),
+		Description: strings.ReplaceAll(match[4], `\"`, `"`),
+	}
+	for _, status := range splitList(match[1]) {
+		for i := range responses {
+			if status == "all" || responses[i].Status == status {
+				responses[i].Headers = append(responses[i].Headers, header)
+			}
+		}
+	}
+}
+
+// mimeTypeAliases maps the MIME type aliases of @Accept and @Produce to MIME types
+var mimeTypeAliases = map[string]string{
+	"json":                  "application/json",
+	"xml":                   "text/xml",
+	"plain":                 "text/plain",
+	"html":                  "text/html",
+	"mpfd":                  "multipart/form-data",
+	"x-www-form-urlencoded": "application/x-www-form-urlencoded",
+	"json-api":              "application/vnd.api+json",
+	"json-stream":           "application/x-json-stream",
+	"octet-stream":          "application/octet-stream",
+	"png":                   "image/png",
+	"jpeg":                  "image/jpeg",
+	"gif":                   "image/gif",
+	"event-stream":          "text/event-stream",
+}
+
+// mimeTypes converts a comma-separated list of MIME types and aliases into MIME types
+func mimeTypes(s string) []string {
+	var types []string
+	for _, name := range splitList(s) {
+		if mimeType, ok := mimeTypeAliases[name]; ok {
+			name = mimeType
+		}
+		types = append(types, name)
+	}
+	return types
+}
+
+// splitList splits a comma-separated list, trimming spaces and dropping empty items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseAPIInfo parses the general API annotations of a comment into info. The annotations
+// following a @securityDefinitions line (@in, @name, @tokenUrl, @scope.x, ...) describe
+// that security scheme.
+func parseAPIInfo(comment string, info *APIInfo) {
+	var scheme *SecurityDefinition
+	for _, line := range strings.Split(comment, "\n") {
+		annotation, rest := splitAnnotation(line)
+		switch {
+		case strings.HasPrefix(annotation, "@securitydefinitions."):
+			kind, flow, _ := strings.Cut(strings.TrimPrefix(annotation, "@securitydefinitions."), ".")
+			info.SecuritySchemes = append(info.SecuritySchemes, SecurityDefinition{Name: rest, Type: kind, Flow: oauth2Flow(flow)})
+			scheme = &info.SecuritySchemes[len(info.SecuritySchemes)-1]
+		case scheme != nil && annotation == "@in":
+			scheme.In = rest
+		case scheme != nil && annotation == "@name":
+			scheme.ParamName = rest
+		case scheme != nil && annotation == "@description":
+			scheme.Description = rest
+		case scheme != nil && annotation == "@tokenurl":
+			scheme.TokenURL = rest
+		case scheme != nil && annotation == "@authorizationurl":
+			scheme.AuthorizationURL = rest
+		case scheme != nil && strings.HasPrefix(annotation, "@scope."):
+			// The scope name keeps its case, so it is read from the original line
+			name := strings.TrimSpace(line)[len("@scope."):len(annotation)]
+			scheme.Scopes = append(scheme.Scopes, Scope{Name: name, Description: rest})
+		case annotation == "@title":
+			info.Title = rest
+		case annotation == "@version":
+			info.Version = rest
+		case annotation == "@description":
+			if info.Description != "" {
+				info.Description += "\n"
+			}
+			info.Description += rest
+		case annotation == "@basepath":
+			info.BasePath = rest
+		}
+	}
+}
+
+// oauth2Flow returns the canonical spelling of an OAuth2 flow name of @securityDefinitions
+func oauth2Flow(flow string) string {
+	if flow == "accesscode" {
+		return "accessCode"
+	}
+	return flow
+}
+
+// splitAnnotation splits a comment line into its lower-cased annotation and the rest of the line
+func splitAnnotation(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "@") {
+		return "", ""
+	}
+	annotation, rest, _ := strings.Cut(line, " ")
+	return strings.ToLower(annotation), strings.TrimSpace(rest)
+}
+
+// parseParameter parses the arguments of a @Param annotation
+// e.g. id path int true "User ID" minimum(1)
+func parseParameter(s string) (Parameter, bool) {
+	match := paramLineRegex.FindStringSubmatch(s)
+	if match == nil {
+		return Parameter{}, false
+	}
+	param := Parameter{
+		Name:        match[1],
+		In:          match[2],
+		Type:        swagTypeRef(match[3]),
+		Required:    strings.EqualFold(match[4], "true"),
+		Description: strings.ReplaceAll(match[5], `\"`, `"`),
+	}
+	for _, attr := range paramAttributeRegex.FindAllStringSubmatch(match[6], -1) {
+		if param.Attributes == nil {
+			param.Attributes = make(map[string]string)
+		}
+		param.Attributes[strings.ToLower(attr[1])] = attr[2]
+	}
+	return param, true
+}
+
+// parseResponses parses the arguments of a @Success annotation, which may declare several
+// comma-separated status codes
+// e.g. 200 {object} UserResponse "OK"
+func parseResponses(s string) []Response {
+	match := responseLineRegex.FindStringSubmatch(s)
+	if match == nil {
+		return nil
+	}
+
+	kind, typeName := match[2], match[3]
+	var typeRef *TypeRef
+	switch {
+	case kind == "array":
+		if elem := swagTypeRef(typeName); elem != nil {
+			typeRef = &TypeRef{Kind: KindArray, Elem: elem}
+		}
+	case typeName != "":
+		typeRef = swagTypeRef(typeName)
+	case kind != "" && kind != "object":
+		// {string} and other primitive kinds without a type name
+		typeRef = swagTypeRef(kind)
+	}
+
+	var responses []Response
+	for _, status := range splitList(match[1]) {
+		responses = append(responses, Response{
+			Status:      status,
+			Type:        typeRef,
+			Description: strings.ReplaceAll(match[4], `\"`, `"`),
+		})
+	}
+	return responses
+}
+
+// swagTypeRef converts a swag type name into a TypeRef, returning nil for nil types
+// e.g. []int -> number[], responses.User -> User
+func swagTypeRef(name string) *TypeRef {
+	switch name {
+	case "", "nil", "null":
+		return nil
+	case "string":
+		return primitiveRef("string")
+	case "int", "integer", "int64", "uint", "uint64":
+		return &TypeRef{Kind: KindPrimitive, Name: "number", Format: "int64"}
+	case "int32", "uint32", "int16", "uint16", "int8", "uint8":
+		return &TypeRef{Kind: KindPrimitive, Name: "number", Format: "int32"}
+	case "number", "float", "float64":
+		return &TypeRef{Kind: KindPrimitive, Name: "number", Format: "double"}
+	case "float32":
+		return &TypeRef{Kind: KindPrimitive, Name: "number", Format: "float"}
+	case "bool", "boolean":
+		return primitiveRef("boolean")
+	case "file":
+		return &TypeRef{Kind: KindPrimitive, Name: "string", Format: "binary"}
+	case "object", "any", "interface{}":
+		return primitiveRef("any")
+	}
+
+	if strings.HasPrefix(name, "[]") {
+		elem := swagTypeRef(strings.TrimPrefix(name, "[]"))
+		if elem == nil {
+			elem = primitiveRef("any")
+		}
+		return &TypeRef{Kind: KindArray, Elem: elem}
+	}
+	if strings.HasPrefix(name, "map[") {
+		if end := strings.Index(name, "]"); end > 0 {
+			elem := swagTypeRef(name[end+1:])
+			if elem == nil {
+				elem = primitiveRef("any")
+			}
+			return &TypeRef{Kind: KindMap, Key: primitiveRef("string"), Elem: elem}
+		}
+	}
+	if open := strings.Index(name, "{"); open > 0 && strings.HasSuffix(name, "}") {
+		return compositeTypeRef(name[:open], name[open+1:len(name)-1])
+	}
+	return namedRef(swaggerTypeName(name))
+}
+
+// compositeTypeRef converts the swag composite syntax Base{field=type,...} into a TypeRef.
+// Field types may be composite themselves, e.g. Envelope{data=Page{items=[]User}}.
+func compositeTypeRef(base, fields string) *TypeRef {
+	ref := &TypeRef{Kind: KindComposite, Name: swaggerTypeName(base)}
+	for _, field := range splitTopLevel(fields) {
+		name, typeName, ok := strings.Cut(field, "=")
+		if !ok {
+			continue
+		}
+		fieldType := swagTypeRef(strings.TrimSpace(typeName))
+		if fieldType == nil {
+			fieldType = primitiveRef("null")
+		}
+		ref.Overrides = append(ref.Overrides, TypeOverride{Name: strings.TrimSpace(name), Type: fieldType})
+	}
+	if len(ref.Overrides) == 0 {
+		return namedRef(ref.Name)
+	}
+	return ref
+}
+
+// splitTopLevel splits a comma-separated list, ignoring commas nested in braces or brackets
+func splitTopLevel(s string) []string {
+	var items []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				items = append(items, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(items, s[start:])
+}
+
+// merge copies the fields that are set in other
+func (info *APIInfo) merge(other APIInfo) {
+	if other.Title != "" {
+		info.Title = other.Title
+	}
+	if other.Version != "" {
+		info.Version = other.Version
+	}
+	if other.Description != "" {
+		info.Description = other.Description
+	}
+	if other.BasePath != "" {
+		info.BasePath = other.BasePath
+	}
+	info.SecuritySchemes = append(info.SecuritySchemes, other.SecuritySchemes...)
+}
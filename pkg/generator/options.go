@@ -0,0 +1,51 @@
+package generator
+
+// Options controls how types are collected and rendered
+type Options struct {
+	// APIRules controls which signals mark a type as API-related
+	APIRules ClassificationRules
+	// APITypesFile, if set, receives the API types instead of the main target file.
+	// Both files import the types they reference from each other.
+	APITypesFile string
+	// TypeMappings maps Go types, keyed by import path and type name (e.g. "github.com/google/uuid.UUID"),
+	// to their JSON representation. They extend and override StdlibTypeMappings.
+	TypeMappings map[string]TypeMapping
+	// TimeAsDate renders time.Time as Date and generates parseX/serializeX helpers that
+	// convert between the JSON strings and Date objects
+	TimeAsDate bool
+	// DiscoverRoutes adds the routes registered with gin, echo, chi or net/http routers to
+	// the operations, inferring their types from the handler bodies. Routes documented with
+	// swag annotations keep their annotated description.
+	DiscoverRoutes bool
+	// InterfaceUnions renders every Go interface as the union of the collected types that
+	// implement it. Interfaces with a //ts:union directive are rendered as unions regardless.
+	InterfaceUnions bool
+	// PropertyNaming converts the Go names of untagged fields to property names. Tag names
+	// are kept as written unless PropertyNamingAll is set.
+	PropertyNaming NamingStrategy
+	// PropertyNamingFunc, if set, converts the property names instead of PropertyNaming
+	PropertyNamingFunc func(name string) string
+	// Readonly selects the types emitted with readonly properties, ReadonlyArray and
+	// Readonly<Record>. Fields tagged ts:"readonly" are readonly in every mode.
+	Readonly ReadonlyMode
+	// TypeNaming transforms the names of the emitted types and every reference to them
+	TypeNaming TypeNaming
+	// PropertyNamingAll applies the naming strategy to the names of tagged fields too
+	PropertyNamingAll bool
+	// FieldTransforms change the collected struct fields, in order
+	FieldTransforms []FieldTransform
+	// TypeTransforms change the collected types, in order
+	TypeTransforms []TypeTransform
+	// Filters leave out the types and fields any of them returns false for
+	Filters []Filter
+	// Package, if set, collects only the files of this Go package directly in the source
+	// directories, without their subdirectories, like $GOPACKAGE under go generate
+	Package string
+}
+
+// DefaultOptions returns the options used by GenerateTypes and GenerateTypesFromMultipleDirs
+func DefaultOptions() Options {
+	return Options{
+		APIRules: DefaultClassificationRules(),
+	}
+}
@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReadonlyMode controls which types are emitted with readonly properties and collections
+type ReadonlyMode string
+
+// Readonly modes
+const (
+	ReadonlyNone      ReadonlyMode = "none"      // Only fields tagged ts:"readonly"
+	ReadonlyAll       ReadonlyMode = "all"       // Every type
+	ReadonlyResponses ReadonlyMode = "responses" // Types only used in responses
+)
+
+// ParseReadonlyMode parses the name of a readonly mode
+func ParseReadonlyMode(s string) (ReadonlyMode, error) {
+	switch mode := ReadonlyMode(strings.TrimSpace(s)); mode {
+	case "", ReadonlyNone:
+		return ReadonlyNone, nil
+	case ReadonlyAll, ReadonlyResponses:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown readonly mode %q (expected none, all or responses)", s)
+}
+
+// tsTagReadonly is the ts tag option marking a field readonly, e.g. ts:"readonly"
+const tsTagReadonly = "readonly"
+
+// hasTSTagOption checks if the ts tag of a struct tag contains an option
+// e.g. ts:"readonly,response-only"
+func hasTSTagOption(tag, option string) bool {
+	for _, value := range strings.Split(extractTag(tag, "ts"), ",") {
+		if strings.TrimSpace(value) == option {
+			return true
+		}
+	}
+	return false
+}
+
+// typeUsage returns the names of the types used in responses and in request bodies, directly
+// as recorded in their endpoints or through the fields of other types used that way
+func typeUsage(types []TypeScriptType) (responses, requests map[string]bool) {
+	byName := make(map[string]*TypeScriptType)
+	for i := range types {
+		byName[types[i].Name] = &types[i]
+	}
+
+	var mark func(used map[string]bool, name string)
+	mark = func(used map[string]bool, name string) {
+		t := byName[name]
+		if t == nil || used[name] {
+			return
+		}
+		used[name] = true
+		for _, field := range t.Fields {
+			for _, ref := range field.TypeRef.NamedTypes() {
+				mark(used, ref)
+			}
+		}
+	}
+
+	responses = make(map[string]bool)
+	requests = make(map[string]bool)
+	for _, t := range types {
+		for _, endpoint := range t.Endpoints {
+			if endpoint.Response {
+				mark(responses, t.Name)
+			}
+			if endpoint.Request {
+				mark(requests, t.Name)
+			}
+		}
+	}
+	return responses, requests
+}
+
+// applyReadonly marks properties readonly and renders their arrays and maps as ReadonlyArray
+// and Readonly<Record>: in every type with ReadonlyAll, in the types used in responses but
+// not in requests with ReadonlyResponses, and in the fields tagged ts:"readonly"
+func applyReadonly(types []TypeScriptType, mode ReadonlyMode) {
+	responses, requests := typeUsage(types)
+	for i := range types {
+		t := &types[i]
+		readonlyType := mode == ReadonlyAll || (mode == ReadonlyResponses && responses[t.Name] && !requests[t.Name])
+		for j := range t.Fields {
+			field := &t.Fields[j]
+			if !readonlyType && !hasTSTagOption(field.Tag, tsTagReadonly) {
+				continue
+			}
+			// Type aliases have a single "value" field holding the aliased type
+			field.Readonly = t.IsInterface
+			field.TypeRef.Walk(func(node *TypeRef) {
+				if node.Kind == KindArray || node.Kind == KindMap {
+					node.Readonly = true
+				}
+			})
+			if field.TypeRef != nil {
+				field.Type = field.TypeRef.String()
+			}
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Renderer renders the model in an output format
+type Renderer interface {
+	Render(model *Model, w io.Writer) error
+}
+
+// FileRenderer is implemented by renderers that write their output files themselves, such
+// as the TypeScript renderer writing the API types to a separate file
+type FileRenderer interface {
+	Renderer
+	RenderFile(model *Model, targetFile string) error
+}
+
+// RendererFunc adapts a function to the Renderer interface
+type RendererFunc func(model *Model, w io.Writer) error
+
+// Render calls f(model, w)
+func (f RendererFunc) Render(model *Model, w io.Writer) error {
+	return f(model, w)
+}
+
+// RenderTarget describes the output a renderer is created for
+type RenderTarget struct {
+	Path      string  // File the output is written to
+	TypesFile string  // File of the TypeScript types, imported by the TypeScript outputs
+	Options   Options // Options the model was collected with
+}
+
+// RendererFactory creates the renderer of an output
+type RendererFactory func(target RenderTarget) Renderer
+
+var (
+	renderersMu sync.RWMutex
+	renderers   = make(map[string]RendererFactory)
+)
+
+// RegisterRenderer makes a renderer available by name, e.g. for the --out flag. It panics
+// if the name is already registered or the factory is nil.
+func RegisterRenderer(name string, factory RendererFactory) {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	if factory == nil {
+		panic("generator: RegisterRenderer factory is nil")
+	}
+	if _, exists := renderers[name]; exists {
+		panic("generator: RegisterRenderer called twice for renderer " + name)
+	}
+	renderers[name] = factory
+}
+
+// LookupRenderer creates the renderer registered by name for the target
+func LookupRenderer(name string, target RenderTarget) (Renderer, error) {
+	renderersMu.RLock()
+	factory, ok := renderers[name]
+	renderersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown renderer %q", name)
+	}
+	return factory(target), nil
+}
+
+// RendererNames returns the sorted names of the registered renderers
+func RendererNames() []string {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RenderToFile writes the output of the renderer to the target file. Renderers implementing
+// FileRenderer write the file themselves.
+func RenderToFile(r Renderer, model *Model, targetFile string) error {
+	if fr, ok := r.(FileRenderer); ok {
+		return fr.RenderFile(model, targetFile)
+	}
+
+	file, err := os.Create(targetFile)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer file.Close()
+
+	return r.Render(model, file)
+}
+
+// TypeScriptRenderer renders the TypeScript type definitions, the default output
+type TypeScriptRenderer struct {
+	Options Options
+}
+
+// Render writes the TypeScript definitions of every type of the model
+func (r TypeScriptRenderer) Render(model *Model, w io.Writer) error {
+	return writeTypeScript(w, model.Types, model.Types, nil, r.Options)
+}
+
+// RenderFile writes the TypeScript definitions to the target file, and the API types to
+// Options.APITypesFile when it is set
+func (r TypeScriptRenderer) RenderFile(model *Model, targetFile string) error {
+	return generateTypeScript(model.Types, targetFile, r.Options)
+}
+
+// typeModuleRenderer returns a renderer of a module importing the TypeScript types of the
+// target, written by generate with the imports of the used type names
+func typeModuleRenderer(target RenderTarget, generate func(*Model, io.Writer, func([]string) []tsImport) error) Renderer {
+	return RendererFunc(func(model *Model, w io.Writer) error {
+		return generate(model, w, func(used []string) []tsImport {
+			return typeModuleImports(model.Types, used, target.Path, target.TypesFile, target.Options)
+		})
+	})
+}
+
+func init() {
+	RegisterRenderer("ts", func(target RenderTarget) Renderer {
+		return TypeScriptRenderer{Options: target.Options}
+	})
+	RegisterRenderer("jsonschema", func(RenderTarget) Renderer {
+		return RendererFunc(GenerateJSONSchema)
+	})
+	RegisterRenderer("openapi", func(RenderTarget) Renderer {
+		return RendererFunc(GenerateOpenAPIYAML)
+	})
+	RegisterRenderer("openapi-json", func(RenderTarget) Renderer {
+		return RendererFunc(GenerateOpenAPIJSON)
+	})
+	RegisterRenderer("ir", func(RenderTarget) Renderer {
+		return RendererFunc(SaveModel)
+	})
+	RegisterRenderer("routes", func(target RenderTarget) Renderer {
+		return typeModuleRenderer(target, generateRoutes)
+	})
+	RegisterRenderer("mocks", func(target RenderTarget) Renderer {
+		return typeModuleRenderer(target, generateMocks)
+	})
+	RegisterRenderer("guards", func(target RenderTarget) Renderer {
+		return typeModuleRenderer(target, generateGuards)
+	})
+	for _, library := range []HookLibrary{HooksTanStack, HooksSWR} {
+		library := library
+		RegisterRenderer(string(library), func(target RenderTarget) Renderer {
+			return typeModuleRenderer(target, func(model *Model, w io.Writer, imports func([]string) []tsImport) error {
+				return generateHooks(model, w, library, imports)
+			})
+		})
+	}
+}
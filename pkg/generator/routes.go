@@ -0,0 +1,319 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// routeHelpers declares the path helpers of the route map. PathParams extracts the
+// parameters of a path with template literal types, which buildPath uses to type its input.
+const routeHelpers = `/**
+ * Names of the parameters of a path, e.g. PathParamNames<"/users/{id}/posts/{postId}"> = "id" | "postId"
+ */
+export type PathParamNames<P extends string> =
+  P extends ` + "`${string}{${infer Name}}${infer Rest}`" + ` ? Name | PathParamNames<Rest> : never;
+
+/**
+ * Parameters of a path, e.g. PathParams<"/users/{id}"> = { id: string | number }
+ */
+export type PathParams<P extends string> = { [K in PathParamNames<P>]: string | number };
+
+/**
+ * Builds a path by replacing its {param} placeholders with the encoded parameter values
+ * e.g. buildPath("/users/{id}", { id: 1 }) = "/users/1"
+ */
+export function buildPath<P extends string>(path: P, params: PathParams<P>): string {
+  const values = params as Record<string, string | number>;
+  return path.replace(/\{([^}]+)\}/g, (_, name: string) => encodeURIComponent(String(values[name])));
+}
+`
+
+// routeTypes declares the accessors of the route map entries
+const routeTypes = `export type RouteKey = keyof Routes;
+export type RouteParams<K extends RouteKey> = Routes[K]["params"];
+export type RouteQuery<K extends RouteKey> = Routes[K]["query"];
+export type RouteBody<K extends RouteKey> = Routes[K]["body"];
+export type RouteResponse<K extends RouteKey> = Routes[K]["response"];
+`
+
+// emptyObjectType is the type of route params and queries without any parameter
+const emptyObjectType = "Record<string, never>"
+
+// routeEntry describes an operation in the route map
+type routeEntry struct {
+	Key           string // "GET /users/{id}"
+	Comment       string
+	PathParams    []routeParam
+	Params        string
+	Query         string // emptyObjectType without query parameters
+	QueryRequired bool   // At least one query parameter is required
+	Body          string // undefined without a request body
+	Response      string
+	Refs          []*TypeRef // Types referenced by the entry
+}
+
+// routeParam is a path parameter of a route
+type routeParam struct {
+	Name string
+	Type string
+}
+
+// GenerateRoutes writes the typed route map of the model's operations, importing the
+// collected types from typesModule (e.g. "./types")
+func GenerateRoutes(model *Model, w io.Writer, typesModule string) error {
+	return generateRoutes(model, w, func(used []string) []tsImport {
+		return []tsImport{{Names: used, From: typesModule}}
+	})
+}
+
+// WriteRoutes writes the typed route map of the model to the target file. The types are
+// imported from typesFile, or from opts.APITypesFile for the API types when it is set.
+func WriteRoutes(model *Model, targetFile, typesFile string, opts Options) error {
+	target := RenderTarget{Path: targetFile, TypesFile: typesFile, Options: opts}
+	return RenderToFile(typeModuleRenderer(target, generateRoutes), model, targetFile)
+}
+
+// typeModuleImports returns the imports of the used type names from the generated
+// TypeScript files, split between typesFile and opts.APITypesFile when it is set
+func typeModuleImports(types []TypeScriptType, used []string, file, typesFile string, opts Options) []tsImport {
+	if opts.APITypesFile == "" {
+		return []tsImport{{Names: used, From: moduleSpecifier(file, typesFile)}}
+	}
+
+	apiTypes := make(map[string]bool)
+	for _, t := range types {
+		if t.IsAPIType {
+			apiTypes[t.Name] = true
+		}
+	}
+	var modelNames, apiNames []string
+	for _, name := range used {
+		if apiTypes[name] {
+			apiNames = append(apiNames, name)
+		} else {
+			modelNames = append(modelNames, name)
+		}
+	}
+
+	var imports []tsImport
+	if len(apiNames) > 0 {
+		imports = append(imports, tsImport{Names: apiNames, From: moduleSpecifier(file, opts.APITypesFile)})
+	}
+	if len(modelNames) > 0 {
+		imports = append(imports, tsImport{Names: modelNames, From: moduleSpecifier(file, typesFile)})
+	}
+	return imports
+}
+
+// generateRoutes writes the route map, with the imports returned by imports for the
+// collected types used by the routes
+func generateRoutes(model *Model, w io.Writer, imports func(used []string) []tsImport) error {
+	var entries []routeEntry
+	seen := make(map[string]bool)
+	for _, op := range model.Operations {
+		entry := newRouteEntry(op)
+		// The first operation wins when several declare the same method and path
+		if seen[entry.Key] {
+			continue
+		}
+		seen[entry.Key] = true
+		entries = append(entries, entry)
+	}
+
+	var b strings.Builder
+	writeModuleHeader(&b, model, entryRefs(entries), nil, imports)
+	b.WriteString(routeHelpers)
+	b.WriteString("\n/**\n * Endpoints keyed by method and path\n */\nexport interface Routes {\n")
+	for _, entry := range entries {
+		if entry.Comment != "" {
+			fmt.Fprintf(&b, "  /** %s */\n", entry.Comment)
+		}
+		fmt.Fprintf(&b, "  %s: {\n", strconv.Quote(entry.Key))
+		fmt.Fprintf(&b, "    params: %s;\n", entry.Params)
+		fmt.Fprintf(&b, "    query: %s;\n", entry.Query)
+		fmt.Fprintf(&b, "    body: %s;\n", entry.Body)
+		fmt.Fprintf(&b, "    response: %s;\n", entry.Response)
+		b.WriteString("  };\n")
+	}
+	b.WriteString("}\n\n")
+	b.WriteString(routeTypes)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeModuleHeader writes the header of a module using the collected types: the library
+// imports, the imports of the collected types referenced by refs and placeholders for the
+// unknown ones
+func writeModuleHeader(b *strings.Builder, model *Model, refs []*TypeRef, libraryImports []string, imports func(used []string) []tsImport) {
+	defined := make(map[string]bool)
+	for _, t := range model.Types {
+		defined[t.Name] = true
+	}
+	usedSet := make(map[string]bool)
+	undefinedSet := make(map[string]bool)
+	for _, ref := range refs {
+		for _, name := range ref.NamedTypes() {
+			if defined[name] {
+				usedSet[name] = true
+			} else if !isReservedTypeName(name) {
+				undefinedSet[name] = true
+			}
+		}
+	}
+	used := sortedKeys(usedSet)
+	undefined := sortedKeys(undefinedSet)
+
+	fmt.Fprintf(b, `// This file is auto-generated. Do not edit directly.
+// Generated at: %s
+
+/* eslint-disable */
+
+`, time.Now().Format("2006-01-02 15:04:05"))
+
+	for _, line := range libraryImports {
+		b.WriteString(line + "\n")
+	}
+	if len(used) > 0 {
+		for _, imp := range imports(used) {
+			fmt.Fprintf(b, "import type { %s } from \"%s\";\n", strings.Join(imp.Names, ", "), imp.From)
+			if len(imp.Values) > 0 {
+				fmt.Fprintf(b, "import { %s } from \"%s\";\n", strings.Join(imp.Values, ", "), imp.From)
+			}
+		}
+	}
+	if len(libraryImports) > 0 || len(used) > 0 {
+		b.WriteString("\n")
+	}
+	if len(undefined) > 0 {
+		b.WriteString("// Placeholders for undefined types\n")
+		for _, name := range undefined {
+			fmt.Fprintf(b, "type %s = any;\n", name)
+		}
+		b.WriteString("\n")
+	}
+}
+
+// entryRefs returns the types referenced by the route entries
+func entryRefs(entries []routeEntry) []*TypeRef {
+	var refs []*TypeRef
+	for _, entry := range entries {
+		refs = append(refs, entry.Refs...)
+	}
+	return refs
+}
+
+// newRouteEntry describes the parameters, body and response of an operation
+func newRouteEntry(op Operation) routeEntry {
+	entry := routeEntry{
+		Key:     strings.ToUpper(op.Method) + " " + op.Path,
+		Comment: op.Summary,
+	}
+	if op.Deprecated {
+		entry.Comment = strings.TrimSpace(entry.Comment + " @deprecated")
+	}
+
+	// Path parameters are typed from their @Param annotation and default to string
+	pathTypes := make(map[string]*TypeRef)
+	var queryTypes, queryFields, formFields []string
+	for _, param := range op.Parameters {
+		switch param.In {
+		case "path":
+			pathTypes[param.Name] = param.Type
+		case "query":
+			// Struct query parameters are used as the query type
+			if param.Type != nil && param.Type.Kind == KindNamed {
+				queryTypes = append(queryTypes, param.Type.String())
+			} else {
+				queryFields = append(queryFields, routeField(param))
+			}
+			entry.Refs = append(entry.Refs, param.Type)
+			entry.QueryRequired = entry.QueryRequired || param.Required
+		case "body":
+			entry.Body = param.Type.String()
+			entry.Refs = append(entry.Refs, param.Type)
+		case "formData":
+			formFields = append(formFields, routeField(param))
+			entry.Refs = append(entry.Refs, param.Type)
+		}
+	}
+
+	var params []string
+	for _, match := range pathParamRegex.FindAllStringSubmatch(op.Path, -1) {
+		ref := pathTypes[match[1]]
+		if ref == nil {
+			ref = primitiveRef("string")
+		}
+		entry.Refs = append(entry.Refs, ref)
+		entry.PathParams = append(entry.PathParams, routeParam{Name: match[1], Type: ref.String()})
+		params = append(params, tsPropertyKey(match[1])+": "+ref.String())
+	}
+	entry.Params = objectType(params)
+
+	if len(queryFields) > 0 {
+		queryTypes = append(queryTypes, objectType(queryFields))
+	}
+	entry.Query = strings.Join(queryTypes, " & ")
+	if entry.Query == "" {
+		entry.Query = emptyObjectType
+	}
+
+	if entry.Body == "" && len(formFields) > 0 {
+		entry.Body = objectType(formFields)
+	}
+	if entry.Body == "" {
+		entry.Body = "undefined"
+	}
+
+	// The response is the union of the success response types
+	var responses []string
+	for _, resp := range op.Responses {
+		if resp.Failure || !strings.HasPrefix(resp.Status, "2") {
+			continue
+		}
+		response := "void"
+		if resp.Type != nil {
+			response = resp.Type.String()
+			entry.Refs = append(entry.Refs, resp.Type)
+		}
+		if !containsString(responses, response) {
+			responses = append(responses, response)
+		}
+	}
+	entry.Response = strings.Join(responses, " | ")
+	if entry.Response == "" {
+		entry.Response = "void"
+	}
+	return entry
+}
+
+// routeField returns the property of a query or form parameter
+func routeField(param Parameter) string {
+	key := tsPropertyKey(param.Name)
+	if !param.Required {
+		key += "?"
+	}
+	return key + ": " + param.Type.String()
+}
+
+// objectType returns an object type literal with the properties
+func objectType(properties []string) string {
+	if len(properties) == 0 {
+		return emptyObjectType
+	}
+	return "{ " + strings.Join(properties, "; ") + " }"
+}
+
+// sortedKeys returns the keys of a set in sorted order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,87 @@
+package generator
+
+// TypeMapping describes how a Go type is represented in JSON
+type TypeMapping struct {
+	TSType string // TypeScript type of the JSON value (e.g. string, number)
+	Format string // Wire format of the value (e.g. date-time, uri)
+	Hint   string // JSDoc hint rendered after the type (e.g. nanoseconds)
+}
+
+// StdlibTypeMappings returns the built-in mappings of standard library types to their JSON
+// representation, keyed by import path and type name (e.g. "time.Duration", "net/netip.Addr").
+// Additional mappings can be provided with Options.TypeMappings.
+func StdlibTypeMappings() map[string]TypeMapping {
+	return map[string]TypeMapping{
+		// time
+		"time.Time":     {TSType: "string", Format: "date-time", Hint: "RFC3339"},
+		"time.Duration": {TSType: "number", Format: "int64", Hint: "nanoseconds"},
+		"time.Month":    {TSType: "number", Format: "int32", Hint: "1-12"},
+		"time.Weekday":  {TSType: "number", Format: "int32", Hint: "0-6, Sunday = 0"},
+
+		// net and net/netip
+		"net.IP":             {TSType: "string", Format: "ip", Hint: "IP address"},
+		"net.HardwareAddr":   {TSType: "string", Format: "byte", Hint: "base64"},
+		"net/netip.Addr":     {TSType: "string", Format: "ip", Hint: "IP address"},
+		"net/netip.AddrPort": {TSType: "string", Hint: "IP address and port"},
+		"net/netip.Prefix":   {TSType: "string", Hint: "CIDR"},
+
+		// math/big
+		"math/big.Int":   {TSType: "number", Hint: "arbitrary precision integer"},
+		"math/big.Float": {TSType: "string", Hint: "arbitrary precision decimal"},
+		"math/big.Rat":   {TSType: "string", Hint: "rational a/b"},
+
+		// encoding/json
+		"encoding/json.Number":     {TSType: "number | string", Hint: "json.Number"},
+		"encoding/json.RawMessage": {TSType: "any", Hint: "raw JSON"},
+
+		// []byte is encoded as a base64 string
+		"[]byte": {TSType: "string", Format: "byte", Hint: "base64"},
+	}
+}
+
+// stdlibStructTypes returns the standard library structs without a JSON or text encoding,
+// which encoding/json writes as objects of their exported fields, keyed by import path and
+// type name. They are declared with the collected types when referenced, under names that
+// do not shadow TypeScript globals such as URL.
+func stdlibStructTypes() map[string]TypeScriptType {
+	field := func(name string, ref *TypeRef) TypeScriptField {
+		return TypeScriptField{Name: name, Type: ref.String(), TypeRef: ref, IsExported: true}
+	}
+	str := func() *TypeRef { return primitiveRef("string") }
+	boolean := func() *TypeRef { return primitiveRef("boolean") }
+
+	return map[string]TypeScriptType{
+		"net/url.URL": {
+			Name:        "NetURL",
+			Comment:     "NetURL is a net/url.URL, written by encoding/json as an object of its fields",
+			IsInterface: true,
+			IsExported:  true,
+			Package:     "url",
+			PackagePath: "net/url",
+			Fields: []TypeScriptField{
+				field("Scheme", str()),
+				field("Opaque", str()),
+				// Userinfo has no exported fields and is written as {}
+				field("User", &TypeRef{Kind: KindPrimitive, Name: "any", Hint: "{} or null"}),
+				field("Host", str()),
+				field("Path", str()),
+				field("Fragment", str()),
+				field("RawQuery", str()),
+				field("RawPath", str()),
+				field("RawFragment", str()),
+				field("ForceQuery", boolean()),
+				field("OmitHost", boolean()),
+			},
+		},
+	}
+}
+
+// stdlibStructType returns the standard library struct declared under a type name
+func stdlibStructType(name string) (TypeScriptType, bool) {
+	for _, t := range stdlibStructTypes() {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return TypeScriptType{}, false
+}
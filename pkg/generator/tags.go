@@ -0,0 +1,21 @@
+package generator
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// extractTag extracts a specific tag value from a tag string with reflect.StructTag semantics
+func extractTag(tag, key string) string {
+	return reflect.StructTag(tag).Get(key)
+}
+
+// structTag returns the raw struct tag from a tag literal, which may be written as a
+// raw string (`json:"name"`) or an interpreted string ("json:\"name\"")
+func structTag(literal string) string {
+	if tag, err := strconv.Unquote(literal); err == nil {
+		return tag
+	}
+	return strings.Trim(literal, "`")
+}
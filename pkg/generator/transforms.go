@@ -0,0 +1,93 @@
+package generator
+
+import "go/ast"
+
+// FieldTransform changes a field collected from a Go struct field. The sources are only
+// parsed, not type-checked, so the hook receives the AST node of the field rather than a
+// types.Object. Changing field.TypeRef changes the emitted type and field.Type is rebuilt
+// from it; changing only field.Type emits it as written, with a primitive TypeRef.
+type FieldTransform func(node *ast.Field, field *TypeScriptField)
+
+// TypeTransform changes a type collected from a Go type declaration, after its fields. Like
+// FieldTransform, it receives the AST node of the declaration.
+type TypeTransform func(node *ast.TypeSpec, t *TypeScriptType)
+
+// Filter decides whether a type or field is collected. node is the *ast.TypeSpec of a type,
+// with a nil field, or the *ast.Field of a struct field.
+type Filter func(node ast.Node, field *TypeScriptField) bool
+
+// Option configures the options of the library functions accepting functional options
+type Option func(*Options)
+
+// WithOptions replaces the options, e.g. to start from options built as a struct
+func WithOptions(opts Options) Option {
+	return func(o *Options) {
+		*o = opts
+	}
+}
+
+// WithFieldTransform adds a transform applied to every collected struct field
+func WithFieldTransform(transform FieldTransform) Option {
+	return func(o *Options) {
+		o.FieldTransforms = append(o.FieldTransforms, transform)
+	}
+}
+
+// WithTypeTransform adds a transform applied to every collected type
+func WithTypeTransform(transform TypeTransform) Option {
+	return func(o *Options) {
+		o.TypeTransforms = append(o.TypeTransforms, transform)
+	}
+}
+
+// WithFilter adds a filter leaving out the types and fields it returns false for
+func WithFilter(filter Filter) Option {
+	return func(o *Options) {
+		o.Filters = append(o.Filters, filter)
+	}
+}
+
+// applyOptions returns the default options changed by the functional options
+func applyOptions(options []Option) Options {
+	opts := DefaultOptions()
+	for _, option := range options {
+		option(&opts)
+	}
+	return opts
+}
+
+// keepNode checks if every filter keeps a type or field
+func keepNode(filters []Filter, node ast.Node, field *TypeScriptField) bool {
+	for _, filter := range filters {
+		if !filter(node, field) {
+			return false
+		}
+	}
+	return true
+}
+
+// transformField applies the field transforms to a collected field and keeps its Type and
+// TypeRef in sync with the one the transforms changed
+func transformField(transforms []FieldTransform, node *ast.Field, field *TypeScriptField) {
+	if len(transforms) == 0 {
+		return
+	}
+	ref, refString, typ := field.TypeRef, field.TypeRef.String(), field.Type
+	for _, transform := range transforms {
+		transform(node, field)
+	}
+
+	switch {
+	case field.TypeRef != nil && (field.TypeRef != ref || field.TypeRef.String() != refString):
+		field.Type = field.TypeRef.String()
+	case field.Type != typ || field.TypeRef == nil:
+		field.TypeRef = primitiveRef(field.Type)
+	}
+}
+
+// transformType applies the type transforms to a collected type
+func transformType(transforms []TypeTransform, node *ast.TypeSpec, t *TypeScriptType) {
+	for _, transform := range transforms {
+		transform(node, t)
+	}
+}
@@ -0,0 +1,169 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnexportedTypes controls how unexported Go types are emitted
+type UnexportedTypes string
+
+// Handling of unexported Go types
+const (
+	UnexportedKeep   UnexportedTypes = "keep"   // Keep the Go name (unexportedType)
+	UnexportedExport UnexportedTypes = "export" // Capitalize the name (UnexportedType)
+	UnexportedSkip   UnexportedTypes = "skip"   // Leave the type out of the output
+)
+
+// TypeNaming controls the names of the emitted types. The transformations are applied in
+// order: trimming, capitalizing unexported names, package qualification, prefix and suffix,
+// and the custom function.
+type TypeNaming struct {
+	TrimPrefixes    []string                 // Prefixes removed from names (e.g. Api)
+	TrimSuffixes    []string                 // Suffixes removed from names (e.g. Response)
+	Unexported      UnexportedTypes          // Handling of unexported types, keep by default
+	QualifyPackages bool                     // Prefix names with their Go package (users.User -> UsersUser)
+	Prefix          string                   // Added before every name (e.g. I)
+	Suffix          string                   // Added after every name (e.g. DTO)
+	Func            func(name string) string // Custom transformation applied last
+}
+
+// ParseUnexportedTypes parses the handling of unexported types
+func ParseUnexportedTypes(s string) (UnexportedTypes, error) {
+	switch value := UnexportedTypes(strings.TrimSpace(s)); value {
+	case "", UnexportedKeep:
+		return UnexportedKeep, nil
+	case UnexportedExport, UnexportedSkip:
+		return value, nil
+	}
+	return "", fmt.Errorf("unknown unexported type handling %q (expected keep, export or skip)", s)
+}
+
+// isZero checks if the naming leaves every name unchanged
+func (n TypeNaming) isZero() bool {
+	return len(n.TrimPrefixes) == 0 && len(n.TrimSuffixes) == 0 && (n.Unexported == "" || n.Unexported == UnexportedKeep) &&
+		!n.QualifyPackages && n.Prefix == "" && n.Suffix == "" && n.Func == nil
+}
+
+// Apply returns the emitted name of a type declared in the given Go package. The package is
+// empty for placeholders of types that were not collected.
+func (n TypeNaming) Apply(name, pkg string) string {
+	for _, prefix := range n.TrimPrefixes {
+		if trimmed := strings.TrimPrefix(name, prefix); trimmed != "" {
+			name = trimmed
+		}
+	}
+	for _, suffix := range n.TrimSuffixes {
+		if trimmed := strings.TrimSuffix(name, suffix); trimmed != "" {
+			name = trimmed
+		}
+	}
+	if n.Unexported == UnexportedExport {
+		name = upperFirst(name)
+	}
+	if n.QualifyPackages && pkg != "" {
+		name = toPascalCase(pkg) + upperFirst(name)
+	}
+	name = n.Prefix + name + n.Suffix
+	if n.Func != nil {
+		name = n.Func(name)
+	}
+	return name
+}
+
+// renameTypes applies the type naming to the model: the names of the types, and every
+// reference to them in fields, operations and classification reasons. Placeholders of types
+// that were not collected are renamed the same way, so they stay consistent with the fields
+// using them. Composite aliases, named by composites, get their comment rebuilt.
+func renameTypes(model *Model, composites map[string]bool, naming TypeNaming) error {
+	if naming.isZero() {
+		return nil
+	}
+
+	// Unexported types are dropped before renaming so their names stay free
+	if naming.Unexported == UnexportedSkip {
+		kept := model.Types[:0]
+		for _, t := range model.Types {
+			if t.IsExported || composites[t.Name] {
+				kept = append(kept, t)
+			}
+		}
+		model.Types = kept
+	}
+
+	names := make(map[string]string)  // Go name -> emitted name
+	owners := make(map[string]string) // Emitted name -> Go name
+	for _, t := range model.Types {
+		newName := naming.Apply(t.Name, t.Package)
+		if !isTSIdentifier(newName) {
+			return fmt.Errorf("type name %s becomes %q, which is not a valid identifier", t.Name, newName)
+		}
+		if owner, exists := owners[newName]; exists {
+			return fmt.Errorf("type names %s and %s both become %s", owner, t.Name, newName)
+		}
+		names[t.Name] = newName
+		owners[newName] = t.Name
+	}
+
+	rename := func(name string) string {
+		if newName, ok := names[name]; ok {
+			return newName
+		}
+		if isReservedTypeName(name) {
+			return name
+		}
+		newName := naming.Apply(name, "")
+		names[name] = newName
+		return newName
+	}
+	// TypeRefs may be shared between fields and operations, so each node is renamed once
+	renamed := make(map[*TypeRef]bool)
+	renameRef := func(ref *TypeRef) {
+		ref.Walk(func(node *TypeRef) {
+			if (node.Kind == KindNamed || node.Kind == KindComposite) && !renamed[node] {
+				node.Name = rename(node.Name)
+				renamed[node] = true
+			}
+		})
+	}
+
+	for i := range model.Types {
+		t := &model.Types[i]
+		composite := composites[t.Name]
+		t.Name = names[t.Name]
+		// Exported names no longer need the note of unexported types; field names are kept,
+		// so the fields keep theirs
+		if naming.Unexported == UnexportedExport {
+			t.IsExported = true
+		}
+		for j := range t.Fields {
+			field := &t.Fields[j]
+			if field.TypeRef != nil {
+				renameRef(field.TypeRef)
+				field.Type = field.TypeRef.String()
+			}
+		}
+		for j, reason := range t.APIReasons {
+			if referrer, ok := strings.CutPrefix(reason, "referenced by "); ok {
+				t.APIReasons[j] = "referenced by " + rename(referrer)
+			}
+		}
+		if composite && len(t.Fields) > 0 {
+			t.Comment = compositeAlias(t.Name, t.Fields[0].TypeRef).Comment
+		}
+	}
+
+	for i := range model.Operations {
+		op := &model.Operations[i]
+		for j := range op.Parameters {
+			renameRef(op.Parameters[j].Type)
+		}
+		for j := range op.Responses {
+			renameRef(op.Responses[j].Type)
+			for k := range op.Responses[j].Headers {
+				renameRef(op.Responses[j].Headers[k].Type)
+			}
+		}
+	}
+	return nil
+}
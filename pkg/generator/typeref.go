@@ -0,0 +1,299 @@
+package generator
+
+import (
+	"go/ast"
+	"strconv"
+	"strings"
+)
+
+// TypeKind represents the kind of a TypeRef node
+type TypeKind string
+
+// Kinds of TypeRef nodes
+const (
+	KindPrimitive TypeKind = "primitive" // string, number, boolean or any
+	KindNamed     TypeKind = "named"     // Reference to a collected or placeholder type
+	KindArray     TypeKind = "array"     // Slice or array of Elem
+	KindMap       TypeKind = "map"       // Map from Key to Elem
+	KindNullable  TypeKind = "nullable"  // Pointer to Elem
+	KindComposite TypeKind = "composite" // Named type with Overrides replacing some of its fields
+	KindUnion     TypeKind = "union"     // One of Members, the implementations of a Go interface
+)
+
+// TypeRef represents the structure of a field type. The TypeScript type string of a field
+// is rendered from it, and other outputs can walk it instead of parsing the string.
+type TypeRef struct {
+	Kind   TypeKind `json:"kind"`
+	Name   string   `json:"name,omitempty"`   // Primitive name or referenced type name
+	Elem   *TypeRef `json:"elem,omitempty"`   // Array element, map value or pointer target
+	Key    *TypeRef `json:"key,omitempty"`    // Map key
+	Format string   `json:"format,omitempty"` // Wire format of well-known types (e.g. date-time, uri)
+	Hint   string   `json:"hint,omitempty"`   // JSDoc hint rendered after the type (e.g. nanoseconds)
+	GoType string   `json:"goType,omitempty"` // Well-known Go type the node was mapped from (e.g. time.Duration)
+
+	Readonly  bool           `json:"readonly,omitempty"`  // Render arrays and maps as ReadonlyArray and Readonly<Record>
+	Overrides []TypeOverride `json:"overrides,omitempty"` // Fields replaced in a composite type
+
+	Members       []*TypeRef `json:"members,omitempty"`       // Members of a union
+	Discriminator string     `json:"discriminator,omitempty"` // Property telling the members of a union apart
+}
+
+// TypeOverride represents a field of a composite type whose type is replaced, as in the
+// swag syntax Envelope{data=[]User}
+type TypeOverride struct {
+	Name string   `json:"name"`
+	Type *TypeRef `json:"type"`
+}
+
+// String renders the TypeRef as a TypeScript type expression
+func (r *TypeRef) String() string {
+	if r == nil {
+		return "any"
+	}
+	switch r.Kind {
+	case KindNamed:
+		return r.Name
+	case KindArray:
+		if r.Readonly {
+			return "ReadonlyArray<" + r.Elem.String() + ">"
+		}
+		// Wrap nullable elements, unions and intersections in parentheses: (User | null)[]
+		if r.Elem != nil && (r.Elem.Kind == KindNullable || r.Elem.Kind == KindComposite || r.Elem.Kind == KindUnion || strings.Contains(r.Elem.Name, " | ")) {
+			return "(" + r.Elem.String() + ")[]"
+		}
+		// Keep hints after the brackets: number[] /* nanoseconds */
+		if r.Elem != nil && r.Elem.Kind == KindPrimitive && r.Elem.Hint != "" {
+			return r.Elem.Name + "[] /* " + r.Elem.Hint + " */"
+		}
+		return r.Elem.String() + "[]"
+	case KindMap:
+		if r.Readonly {
+			return "Readonly<Record<" + r.Key.String() + ", " + r.Elem.String() + ">>"
+		}
+		return "Record<" + r.Key.String() + ", " + r.Elem.String() + ">"
+	case KindNullable:
+		return r.Elem.String() + " | null"
+	case KindUnion:
+		members := make([]string, len(r.Members))
+		for i, member := range r.Members {
+			members[i] = member.String()
+		}
+		return strings.Join(members, " | ")
+	case KindComposite:
+		// Omit<Envelope, "data"> & { data: User[] }
+		names := make([]string, len(r.Overrides))
+		fields := make([]string, len(r.Overrides))
+		for i, override := range r.Overrides {
+			names[i] = strconv.Quote(override.Name)
+			fields[i] = tsPropertyKey(override.Name) + ": " + override.Type.String()
+		}
+		return "Omit<" + r.Name + ", " + strings.Join(names, " | ") + "> & { " + strings.Join(fields, "; ") + " }"
+	default:
+		if r.Hint != "" {
+			return r.Name + " /* " + r.Hint + " */"
+		}
+		return r.Name
+	}
+}
+
+// IsNullable reports whether the type or its array elements are nullable
+func (r *TypeRef) IsNullable() bool {
+	if r == nil {
+		return false
+	}
+	if r.Kind == KindArray && r.Elem != nil {
+		return r.Elem.Kind == KindNullable
+	}
+	return r.Kind == KindNullable
+}
+
+// Walk calls fn for the node and all of its descendants, depth first
+func (r *TypeRef) Walk(fn func(*TypeRef)) {
+	if r == nil {
+		return
+	}
+	fn(r)
+	r.Key.Walk(fn)
+	r.Elem.Walk(fn)
+	for _, override := range r.Overrides {
+		override.Type.Walk(fn)
+	}
+	for _, member := range r.Members {
+		member.Walk(fn)
+	}
+}
+
+// NamedTypes returns the names of the types referenced by the TypeRef
+func (r *TypeRef) NamedTypes() []string {
+	var names []string
+	r.Walk(func(node *TypeRef) {
+		if node.Kind == KindNamed || node.Kind == KindComposite {
+			names = append(names, node.Name)
+		}
+	})
+	return names
+}
+
+// Clone returns a deep copy of the TypeRef
+func (r *TypeRef) Clone() *TypeRef {
+	if r == nil {
+		return nil
+	}
+	clone := *r
+	clone.Key = r.Key.Clone()
+	clone.Elem = r.Elem.Clone()
+	if r.Overrides != nil {
+		clone.Overrides = make([]TypeOverride, len(r.Overrides))
+		for i, override := range r.Overrides {
+			clone.Overrides[i] = TypeOverride{Name: override.Name, Type: override.Type.Clone()}
+		}
+	}
+	if r.Members != nil {
+		clone.Members = make([]*TypeRef, len(r.Members))
+		for i, member := range r.Members {
+			clone.Members[i] = member.Clone()
+		}
+	}
+	return &clone
+}
+
+// primitiveRef returns a TypeRef for a primitive TypeScript type
+func primitiveRef(name string) *TypeRef {
+	return &TypeRef{Kind: KindPrimitive, Name: name}
+}
+
+// namedRef returns a TypeRef referencing a named type
+func namedRef(name string) *TypeRef {
+	return &TypeRef{Kind: KindNamed, Name: name}
+}
+
+// typeConverter converts Go type expressions into TypeRefs
+type typeConverter struct {
+	imports  map[string]string      // Local package name -> import path
+	mappings map[string]TypeMapping // Qualified Go type name -> mapping
+}
+
+// newTypeConverter creates a converter for the given file, using the built-in standard
+// library mappings extended by the given ones
+func newTypeConverter(file *ast.File, mappings map[string]TypeMapping) *typeConverter {
+	c := &typeConverter{
+		imports:  make(map[string]string),
+		mappings: StdlibTypeMappings(),
+	}
+	for goType, mapping := range mappings {
+		c.mappings[goType] = mapping
+	}
+	if file != nil {
+		for _, imp := range file.Imports {
+			path, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				continue
+			}
+			name := importName(path)
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			c.imports[name] = path
+		}
+	}
+	return c
+}
+
+// importName returns the default package name for an import path
+// e.g. net/url -> url, gopkg.in/yaml.v3 -> yaml, github.com/jackc/pgx/v5 -> pgx
+func importName(path string) string {
+	parts := strings.Split(path, "/")
+	name := parts[len(parts)-1]
+	if len(parts) > 1 && len(name) > 1 && name[0] == 'v' && isDigits(name[1:]) {
+		name = parts[len(parts)-2]
+	}
+	if i := strings.Index(name, ".v"); i > 0 && isDigits(name[i+2:]) {
+		name = name[:i]
+	}
+	return name
+}
+
+// isDigits checks if a non-empty string contains only ASCII digits
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// convert converts a Go type expression into a TypeRef
+func (c *typeConverter) convert(expr ast.Expr) *TypeRef {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return primitiveRef("string")
+		case "bool":
+			return primitiveRef("boolean")
+		case "int", "int64", "uint", "uint32", "uint64", "uintptr":
+			return &TypeRef{Kind: KindPrimitive, Name: "number", Format: "int64"}
+		case "int8", "int16", "int32", "uint8", "uint16", "byte", "rune":
+			return &TypeRef{Kind: KindPrimitive, Name: "number", Format: "int32"}
+		case "float32":
+			return &TypeRef{Kind: KindPrimitive, Name: "number", Format: "float"}
+		case "float64":
+			return &TypeRef{Kind: KindPrimitive, Name: "number", Format: "double"}
+		case "any":
+			return primitiveRef("any")
+		default:
+			// Return type name as is without converting to PascalCase
+			return namedRef(t.Name)
+		}
+	case *ast.ArrayType:
+		// []byte is encoded as a base64 string by encoding/json
+		if ident, ok := t.Elt.(*ast.Ident); ok && t.Len == nil && (ident.Name == "byte" || ident.Name == "uint8") {
+			if ref := c.mapped("[]byte"); ref != nil {
+				return ref
+			}
+		}
+		return &TypeRef{Kind: KindArray, Elem: c.convert(t.Elt)}
+	case *ast.MapType:
+		return &TypeRef{Kind: KindMap, Key: c.convert(t.Key), Elem: c.convert(t.Value)}
+	case *ast.SelectorExpr:
+		if ident, ok := t.X.(*ast.Ident); ok {
+			path, imported := c.imports[ident.Name]
+			if !imported {
+				path = ident.Name
+			}
+			if ref := c.mapped(path + "." + t.Sel.Name); ref != nil {
+				return ref
+			}
+			if st, ok := stdlibStructTypes()[path+"."+t.Sel.Name]; ok {
+				return namedRef(st.Name)
+			}
+		}
+		return namedRef(t.Sel.Name)
+	case *ast.StarExpr:
+		// For pointer types, get the base type and mark it as nullable
+		return &TypeRef{Kind: KindNullable, Elem: c.convert(t.X)}
+	case *ast.InterfaceType:
+		return primitiveRef("any")
+	default:
+		return primitiveRef("any")
+	}
+}
+
+// mapped returns the TypeRef for a Go type with a known mapping, or nil
+func (c *typeConverter) mapped(goType string) *TypeRef {
+	mapping, ok := c.mappings[goType]
+	if !ok {
+		return nil
+	}
+	return &TypeRef{
+		Kind:   KindPrimitive,
+		Name:   mapping.TSType,
+		Format: mapping.Format,
+		Hint:   mapping.Hint,
+		GoType: goType,
+	}
+}
@@ -0,0 +1,341 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// unionDirective is the comment directive declaring the members of a Go interface, e.g.
+// //ts:union Circle,Square discriminator=kind. Without members, the implementations are
+// detected by type-checking the source directories.
+const unionDirective = "//ts:union"
+
+// unionDecl is an interface type rendered as the union of its implementations
+type unionDecl struct {
+	Name          string
+	Dir           string        // Directory of the package declaring the interface
+	Members       []unionMember // Members listed in the directive
+	Discriminator string        // JSON property telling the members apart
+	Declared      bool          // Whether the interface has a //ts:union directive
+}
+
+// unionMember is a member of a union with the value of its discriminator property
+type unionMember struct {
+	Name  string
+	Value string
+}
+
+// unionSources holds the interfaces and the package files found in the source directories
+type unionSources struct {
+	interfaces map[string]*unionDecl
+	order      []string          // Interface names in declaration order
+	typeDirs   map[string]string // Type name -> directory of the first package declaring it
+
+	fset     *token.FileSet
+	parsed   map[string]bool                   // Parsed file paths
+	files    map[string]map[string][]*ast.File // Directory -> package name -> files
+	paths    packagePathCache
+	packages map[string]*types.Package // Type-checked packages by directory
+	checking map[string]bool           // Packages being type-checked, to stop import cycles
+	importer types.Importer            // Importer of the packages outside the source directories
+}
+
+// applyInterfaceUnions renders the interfaces of the collected types as unions of their
+// implementations: the members listed in their //ts:union directive or, for interfaces with
+// an empty directive or all interfaces when auto is set, the collected types implementing the
+// interface with a value or pointer receiver. The discriminator property of each member is
+// typed as a string literal, its listed value or the type name by default.
+func applyInterfaceUnions(ctx context.Context, sourceDirs []string, typeMap map[string]*TypeScriptType, typeOrder []string, auto bool) error {
+	sources := &unionSources{
+		interfaces: make(map[string]*unionDecl),
+		typeDirs:   make(map[string]string),
+		fset:       token.NewFileSet(),
+		parsed:     make(map[string]bool),
+		files:      make(map[string]map[string][]*ast.File),
+		paths:      make(packagePathCache),
+		packages:   make(map[string]*types.Package),
+		checking:   make(map[string]bool),
+		importer:   importer.Default(),
+	}
+	for _, sourceDir := range sourceDirs {
+		if err := sources.collect(ctx, sourceDir); err != nil {
+			return fmt.Errorf("error collecting interfaces from directory %s: %w", sourceDir, err)
+		}
+	}
+
+	for _, name := range sources.order {
+		decl := sources.interfaces[name]
+		t := typeMap[name]
+		if t == nil || t.IsInterface || len(t.Fields) == 0 || (!decl.Declared && !auto) {
+			continue
+		}
+
+		members := decl.Members
+		if len(members) == 0 {
+			iface := sources.interfaceType(decl)
+			if iface == nil || iface.NumMethods() == 0 {
+				// Every type implements the empty interface
+				continue
+			}
+			for _, candidate := range typeOrder {
+				if _, isInterface := sources.interfaces[candidate]; !isInterface && sources.implements(candidate, iface) {
+					members = append(members, unionMember{Name: candidate, Value: candidate})
+				}
+			}
+		}
+
+		union := &TypeRef{Kind: KindUnion, Discriminator: decl.Discriminator}
+		for _, member := range members {
+			if typeMap[member.Name] == nil {
+				return fmt.Errorf("union %s: unknown member type %s", name, member.Name)
+			}
+			union.Members = append(union.Members, namedRef(member.Name))
+			if decl.Discriminator != "" {
+				setDiscriminator(typeMap[member.Name], decl.Discriminator, member.Value, name)
+			}
+		}
+		if len(union.Members) == 0 {
+			continue
+		}
+
+		field := &t.Fields[0]
+		field.TypeRef = union
+		field.Type = union.String()
+	}
+	return nil
+}
+
+// setDiscriminator types the discriminator property of a union member as a string literal,
+// adding the property when the struct does not declare it
+func setDiscriminator(t *TypeScriptType, property, value, unionName string) {
+	if !t.IsInterface {
+		return
+	}
+	ref := primitiveRef(strconv.Quote(value))
+	for i := range t.Fields {
+		if t.Fields[i].Name == property {
+			t.Fields[i].TypeRef = ref
+			t.Fields[i].Type = ref.String()
+			t.Fields[i].Optional = false
+			return
+		}
+	}
+	// The property is written by custom JSON marshaling
+	t.Fields = append(t.Fields, TypeScriptField{
+		Name:       property,
+		Type:       ref.String(),
+		TypeRef:    ref,
+		Comment:    "Discriminator of the " + unionName + " union",
+		IsExported: true,
+	})
+}
+
+// discriminatorValue returns the string literal value of the discriminator property of a
+// union member
+func discriminatorValue(t TypeScriptType, property string) (string, bool) {
+	for _, field := range t.Fields {
+		if field.Name != property || field.TypeRef == nil || field.TypeRef.Kind != KindPrimitive {
+			continue
+		}
+		if value, err := strconv.Unquote(field.TypeRef.Name); err == nil {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// collect parses the interfaces and package files of a source directory
+func (s *unionSources) collect(ctx context.Context, sourceDir string) error {
+	return walkContext(ctx, sourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Process only Go files, once when source directories overlap
+		if info.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		if s.parsed[absPath] {
+			return nil
+		}
+		s.parsed[absPath] = true
+
+		node, err := parser.ParseFile(s.fset, path, nil, parser.ParseComments)
+		if err != nil {
+			fmt.Printf("Error parsing file %s: %v\n", path, err)
+			return nil
+		}
+
+		// Test files are not part of the package implementing the interfaces
+		dir := filepath.Dir(absPath)
+		if !strings.HasSuffix(path, "_test.go") {
+			if s.files[dir] == nil {
+				s.files[dir] = make(map[string][]*ast.File)
+			}
+			s.files[dir][node.Name.Name] = append(s.files[dir][node.Name.Name], node)
+		}
+
+		for _, decl := range node.Decls {
+			d, ok := decl.(*ast.GenDecl)
+			if !ok || d.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range d.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				if _, exists := s.typeDirs[typeSpec.Name.Name]; !exists {
+					s.typeDirs[typeSpec.Name.Name] = dir
+				}
+				if _, ok := typeSpec.Type.(*ast.InterfaceType); !ok {
+					continue
+				}
+				if _, exists := s.interfaces[typeSpec.Name.Name]; exists {
+					continue
+				}
+				union := &unionDecl{Name: typeSpec.Name.Name, Dir: dir}
+				for _, doc := range []*ast.CommentGroup{d.Doc, typeSpec.Doc} {
+					if hasDirective(doc, unionDirective) {
+						union.Declared = true
+						union.Members, union.Discriminator = parseUnionDirective(doc)
+					}
+				}
+				s.interfaces[union.Name] = union
+				s.order = append(s.order, union.Name)
+			}
+		}
+		return nil
+	})
+}
+
+// interfaceType returns the type-checked interface of a declaration
+func (s *unionSources) interfaceType(decl *unionDecl) *types.Interface {
+	pkg := s.typeCheck(decl.Dir)
+	if pkg == nil {
+		return nil
+	}
+	obj, ok := pkg.Scope().Lookup(decl.Name).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	iface, _ := obj.Type().Underlying().(*types.Interface)
+	return iface
+}
+
+// implements checks if a type implements an interface, with value or pointer receivers,
+// including the methods promoted from embedded fields
+func (s *unionSources) implements(typeName string, iface *types.Interface) bool {
+	dir, ok := s.typeDirs[typeName]
+	if !ok {
+		return false
+	}
+	pkg := s.typeCheck(dir)
+	if pkg == nil {
+		return false
+	}
+	obj, ok := pkg.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return false
+	}
+	t := obj.Type()
+	if named, ok := t.(*types.Named); ok && named.TypeParams().Len() > 0 {
+		// Generic types implement interfaces once instantiated
+		return false
+	}
+	if types.IsInterface(t) {
+		return false
+	}
+	return types.Implements(t, iface) || types.Implements(types.NewPointer(t), iface)
+}
+
+// typeCheck returns the type-checked package of a source directory. Type errors, such as
+// imports that cannot be resolved, are ignored: the affected types are invalid and do not
+// implement the interfaces using them.
+func (s *unionSources) typeCheck(dir string) *types.Package {
+	if pkg, ok := s.packages[dir]; ok {
+		return pkg
+	}
+	if s.checking[dir] {
+		return nil
+	}
+	s.checking[dir] = true
+	defer delete(s.checking, dir)
+
+	// Directories with several packages are checked with their main package
+	var files []*ast.File
+	for name, pkgFiles := range s.files[dir] {
+		if files == nil || len(pkgFiles) > len(files) || (len(pkgFiles) == len(files) && name < files[0].Name.Name) {
+			files = pkgFiles
+		}
+	}
+	if files == nil {
+		return nil
+	}
+
+	conf := types.Config{
+		Importer:    s,
+		Error:       func(error) {},
+		FakeImportC: true,
+	}
+	pkg, _ := conf.Check(s.paths.lookup(dir), s.fset, files, nil)
+	s.packages[dir] = pkg
+	return pkg
+}
+
+// Import imports a package of the source directories from its files, and other packages
+// with the default importer
+func (s *unionSources) Import(path string) (*types.Package, error) {
+	for dir := range s.files {
+		if s.paths.lookup(dir) == path {
+			if pkg := s.typeCheck(dir); pkg != nil {
+				return pkg, nil
+			}
+			return nil, fmt.Errorf("import cycle through %s", path)
+		}
+	}
+	return s.importer.Import(path)
+}
+
+// parseUnionDirective parses the members and discriminator of a //ts:union directive
+// e.g. //ts:union Circle=circle,Square discriminator=kind
+func parseUnionDirective(doc *ast.CommentGroup) ([]unionMember, string) {
+	var members []unionMember
+	discriminator := ""
+	for _, c := range doc.List {
+		text := strings.TrimSpace(c.Text)
+		if text != unionDirective && !strings.HasPrefix(text, unionDirective+" ") {
+			continue
+		}
+		for _, token := range strings.Fields(strings.TrimPrefix(text, unionDirective)) {
+			if value, ok := strings.CutPrefix(token, "discriminator="); ok {
+				discriminator = value
+				continue
+			}
+			for _, item := range strings.Split(token, ",") {
+				if item == "" {
+					continue
+				}
+				name, value, ok := strings.Cut(item, "=")
+				if !ok {
+					value = name
+				}
+				members = append(members, unionMember{Name: name, Value: value})
+			}
+		}
+	}
+	return members, discriminator
+}
@@ -0,0 +1,152 @@
+package generator
+
+import "strings"
+
+// tsTagResponseOnly is the ts tag option of fields set by the server, e.g. ts:"response-only".
+// Like ts:"readonly" fields, they are left out of the input variants.
+const tsTagResponseOnly = "response-only"
+
+// inputVariantSuffix is appended to the names of the input variants
+const inputVariantSuffix = "Input"
+
+// isResponseOnlyField checks if a field is set by the server and left out of input variants
+func isResponseOnlyField(field TypeScriptField) bool {
+	return hasTSTagOption(field.Tag, tsTagReadonly) || hasTSTagOption(field.Tag, tsTagResponseOnly)
+}
+
+// applyInputVariants adds an XInput variant, without the fields tagged ts:"readonly" or
+// ts:"response-only", for every struct used in request bodies that has such fields or
+// references a type with an input variant. The input variants take over the request
+// endpoints of the types and the body parameters of the operations reference them. Types
+// only used in requests, and not referenced by other types, become their input variant
+// and keep their name.
+func applyInputVariants(types []TypeScriptType, operations []Operation) []TypeScriptType {
+	responses, requests := typeUsage(types)
+
+	names := make(map[string]bool)
+	for _, t := range types {
+		names[t.Name] = true
+	}
+
+	// Types needing an input variant, until no more are found through their fields. Types
+	// whose variant name is already taken keep a single variant.
+	inputs := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, t := range types {
+			if inputs[t.Name] || !t.IsInterface || !requests[t.Name] || names[t.Name+inputVariantSuffix] {
+				continue
+			}
+			for _, field := range t.Fields {
+				if isResponseOnlyField(field) || referencesAny(field.TypeRef, inputs) {
+					inputs[t.Name] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	if len(inputs) == 0 {
+		return types
+	}
+
+	// Types only used in requests become their input variant and keep their name
+	inputNames := make(map[string]string)
+	for name := range inputs {
+		if !responses[name] && !referencedOutsideInputs(name, types, inputs) {
+			inputNames[name] = name
+		} else {
+			inputNames[name] = name + inputVariantSuffix
+		}
+	}
+	renameInputs := func(ref *TypeRef) {
+		ref.Walk(func(node *TypeRef) {
+			if node.Kind == KindNamed || node.Kind == KindComposite {
+				if name, ok := inputNames[node.Name]; ok {
+					node.Name = name
+				}
+			}
+		})
+	}
+
+	var result []TypeScriptType
+	for _, t := range types {
+		if !inputs[t.Name] {
+			result = append(result, t)
+			continue
+		}
+
+		input := t
+		input.Name = inputNames[t.Name]
+		input.Fields = nil
+		input.Endpoints = nil
+		for _, field := range t.Fields {
+			if isResponseOnlyField(field) {
+				continue
+			}
+			field.TypeRef = field.TypeRef.Clone()
+			renameInputs(field.TypeRef)
+			if field.TypeRef != nil {
+				field.Type = field.TypeRef.String()
+			}
+			input.Fields = append(input.Fields, field)
+		}
+		if input.Name == t.Name {
+			input.Endpoints = t.Endpoints
+			result = append(result, input)
+			continue
+		}
+
+		input.Comment = "Input variant of " + t.Name + " without the fields set by the server"
+		if comment := strings.TrimSpace(t.Comment); comment != "" {
+			input.Comment = comment + "\n\n" + input.Comment
+		}
+
+		// The request endpoints move to the input variant
+		var responseEndpoints []EndpointInfo
+		for _, endpoint := range t.Endpoints {
+			if endpoint.Request {
+				input.Endpoints = append(input.Endpoints, endpoint)
+			} else {
+				responseEndpoints = append(responseEndpoints, endpoint)
+			}
+		}
+		t.Endpoints = responseEndpoints
+		result = append(result, t, input)
+	}
+
+	for i := range operations {
+		for j := range operations[i].Parameters {
+			if param := &operations[i].Parameters[j]; param.In == "body" {
+				renameInputs(param.Type)
+			}
+		}
+	}
+	return result
+}
+
+// referencesAny checks if a TypeRef references one of the named types
+func referencesAny(ref *TypeRef, names map[string]bool) bool {
+	for _, name := range ref.NamedTypes() {
+		if names[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// referencedOutsideInputs checks if a type is referenced by a type without an input variant,
+// which keeps referencing the original type
+func referencedOutsideInputs(name string, types []TypeScriptType, inputs map[string]bool) bool {
+	for _, t := range types {
+		if inputs[t.Name] {
+			continue
+		}
+		for _, field := range t.Fields {
+			if referencesAny(field.TypeRef, map[string]bool{name: true}) {
+				return true
+			}
+		}
+	}
+	return false
+}